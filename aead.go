@@ -0,0 +1,228 @@
+package encodingx
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/aura-studio/reflectx"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var (
+	ErrAESGCMWrongValueType           = errors.New("encoding AESGCM converts on wrong type value")
+	ErrChaCha20Poly1305WrongValueType = errors.New("encoding ChaCha20Poly1305 converts on wrong type value")
+)
+
+// The encrypting encodings are not registered by default, as they need a
+// key. Register a configured instance to use it in chains:
+//
+//	encodingx.MustRegister(encodingx.NewAESGCM(encodingx.StaticKey(key)))
+//	chain := encodingx.MustParseChain("MsgPack|AESGCM|Base64URL")
+
+// ============================================================================
+// AESGCM - AES-GCM authenticated encryption
+// Format: [1 byte key ID length] + [key ID] + [12 bytes random nonce] + [ciphertext] + [16 bytes tag]
+// Keys are 16, 24 or 32 bytes, for AES-128, AES-192 or AES-256. The key ID
+// and WithAssociatedData are authenticated along with the data.
+// ============================================================================
+
+type AESGCM struct {
+	options
+	keys KeyProvider
+}
+
+// NewAESGCM creates an AESGCM encoding sealing with the current key of keys,
+// honoring WithName and WithAssociatedData.
+func NewAESGCM(keys KeyProvider, opts ...AEADOption) *AESGCM {
+	return &AESGCM{
+		options: newOptions(opts, AEADOption.applyAEAD),
+		keys:    keys,
+	}
+}
+
+func (a AESGCM) String() string {
+	return a.nameOr(reflectx.TypeName(a))
+}
+
+func (AESGCM) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (a AESGCM) Marshal(v interface{}) ([]byte, error) {
+	return a.AppendMarshal(nil, v)
+}
+
+func (a AESGCM) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrAESGCMWrongValueType
+	}
+	return aesGCMFormat.appendSeal(dst, data, a.keys, a.associatedData())
+}
+
+// Unmarshal fails with ErrAuthentication if data was not sealed with one of
+// the keys, or was altered since.
+func (a AESGCM) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		opened, err := aesGCMFormat.open(data, a.keys, a.associatedData())
+		if err != nil {
+			return err
+		}
+		v.Data = opened
+		return nil
+	default:
+		return ErrAESGCMWrongValueType
+	}
+}
+
+func (a AESGCM) Reverse() Encoding {
+	return a
+}
+
+// ============================================================================
+// ChaCha20Poly1305 - ChaCha20-Poly1305 authenticated encryption (RFC 8439)
+// Format: [1 byte key ID length] + [key ID] + [12 bytes random nonce] + [ciphertext] + [16 bytes tag]
+// Keys are 32 bytes. The key ID and WithAssociatedData are authenticated
+// along with the data.
+// ============================================================================
+
+type ChaCha20Poly1305 struct {
+	options
+	keys KeyProvider
+}
+
+// NewChaCha20Poly1305 creates a ChaCha20Poly1305 encoding sealing with the
+// current key of keys, honoring WithName and WithAssociatedData.
+func NewChaCha20Poly1305(keys KeyProvider, opts ...AEADOption) *ChaCha20Poly1305 {
+	return &ChaCha20Poly1305{
+		options: newOptions(opts, AEADOption.applyAEAD),
+		keys:    keys,
+	}
+}
+
+func (c ChaCha20Poly1305) String() string {
+	return c.nameOr(reflectx.TypeName(c))
+}
+
+func (ChaCha20Poly1305) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (c ChaCha20Poly1305) Marshal(v interface{}) ([]byte, error) {
+	return c.AppendMarshal(nil, v)
+}
+
+func (c ChaCha20Poly1305) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrChaCha20Poly1305WrongValueType
+	}
+	return chaCha20Poly1305Format.appendSeal(dst, data, c.keys, c.associatedData())
+}
+
+// Unmarshal fails with ErrAuthentication if data was not sealed with one of
+// the keys, or was altered since.
+func (c ChaCha20Poly1305) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		opened, err := chaCha20Poly1305Format.open(data, c.keys, c.associatedData())
+		if err != nil {
+			return err
+		}
+		v.Data = opened
+		return nil
+	default:
+		return ErrChaCha20Poly1305WrongValueType
+	}
+}
+
+func (c ChaCha20Poly1305) Reverse() Encoding {
+	return c
+}
+
+// aeadFormat seals and opens the key ID framing shared by the AEAD
+// encodings.
+type aeadFormat struct {
+	newAEAD func(key []byte) (cipher.AEAD, error)
+}
+
+var (
+	aesGCMFormat = &aeadFormat{
+		newAEAD: func(key []byte) (cipher.AEAD, error) {
+			block, err := aes.NewCipher(key)
+			if err != nil {
+				return nil, err
+			}
+			return cipher.NewGCM(block)
+		},
+	}
+	chaCha20Poly1305Format = &aeadFormat{
+		newAEAD: chacha20poly1305.New,
+	}
+)
+
+func (f *aeadFormat) appendSeal(dst, data []byte, keys KeyProvider, associatedData []byte) ([]byte, error) {
+	if keys == nil {
+		return nil, ErrKeyNotFound
+	}
+	id, key, err := keys.CurrentKey()
+	if err != nil {
+		return nil, err
+	}
+	aead, err := f.newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	start := len(dst)
+	dst, err = appendKeyID(dst, id)
+	if err != nil {
+		return nil, err
+	}
+	additionalData := aeadAdditionalData(dst[start:], associatedData)
+
+	nonceSize := aead.NonceSize()
+	dst = slices.Grow(dst, nonceSize+len(data)+aead.Overhead())
+	nonce := dst[len(dst) : len(dst)+nonceSize]
+	rand.Read(nonce)
+	return aead.Seal(dst[:len(dst)+nonceSize], nonce, data, additionalData), nil
+}
+
+func (f *aeadFormat) open(data []byte, keys KeyProvider, associatedData []byte) ([]byte, error) {
+	if keys == nil {
+		return nil, ErrKeyNotFound
+	}
+	id, header, sealed, ok := readKeyID(data)
+	if !ok {
+		return nil, ErrAuthentication
+	}
+	key, err := keys.Key(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAuthentication, err)
+	}
+	aead, err := f.newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := aead.NonceSize()
+	if len(sealed) < nonceSize+aead.Overhead() {
+		return nil, ErrAuthentication
+	}
+	opened, err := aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], aeadAdditionalData(header, associatedData))
+	if err != nil {
+		return nil, ErrAuthentication
+	}
+	return opened, nil
+}
+
+// aeadAdditionalData authenticates the key ID header before the associated
+// data; the header holds its own length, so the two cannot be confused.
+func aeadAdditionalData(header, associatedData []byte) []byte {
+	return append(slices.Clone(header), associatedData...)
+}
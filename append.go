@@ -0,0 +1,35 @@
+package encodingx
+
+import (
+	"sync"
+)
+
+// AppendMarshaler is implemented by bytes style encodings that can write
+// their output into a caller supplied buffer. AppendMarshal appends the
+// encoded form of v to dst and returns the extended buffer; the result
+// never aliases the bytes of v.
+type AppendMarshaler interface {
+	AppendMarshal(dst []byte, v interface{}) ([]byte, error)
+}
+
+// maxPooledBuffer keeps single oversized payloads from pinning memory.
+const maxPooledBuffer = 1 << 20
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 512)
+		return &buf
+	},
+}
+
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+func putBuffer(buf *[]byte) {
+	if cap(*buf) > maxPooledBuffer {
+		return
+	}
+	*buf = (*buf)[:0]
+	bufferPool.Put(buf)
+}
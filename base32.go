@@ -0,0 +1,341 @@
+package encodingx
+
+import (
+	"encoding/base32"
+	"errors"
+	"io"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrBase32WrongValueType          = errors.New("encoding base32 converts on wrong type value")
+	ErrBase32HexWrongValueType       = errors.New("encoding base32Hex converts on wrong type value")
+	ErrBase32RawWrongValueType       = errors.New("encoding base32Raw converts on wrong type value")
+	ErrBase32CrockfordWrongValueType = errors.New("encoding base32Crockford converts on wrong type value")
+	ErrBase32CrockfordInvalidData    = errors.New("encoding base32Crockford invalid data")
+	ErrBase32CrockfordCheckMismatch  = errors.New("encoding base32Crockford check symbol mismatch")
+)
+
+func init() {
+	register(NewBase32())
+	register(NewBase32Hex())
+	register(NewBase32Raw())
+	register(NewBase32Crockford())
+	register(NewBase32Crockford(WithName("Base32CrockfordCheck"), WithCheckSymbol()))
+}
+
+// ============================================================================
+// Base32 - RFC 4648 base32 with padding, e.g. for TOTP secrets
+// ============================================================================
+
+type Base32 struct{}
+
+func NewBase32() *Base32 {
+	return new(Base32)
+}
+
+func (b Base32) String() string {
+	return reflectx.TypeName(b)
+}
+
+func (Base32) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base32) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base32) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase32WrongValueType
+	}
+	return base32.StdEncoding.AppendEncode(dst, data), nil
+}
+
+func (Base32) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := base32.StdEncoding.DecodeString(string(data))
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase32WrongValueType
+	}
+}
+
+func (Base32) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(base32.NewEncoder(base32.StdEncoding, w), ErrBase32WrongValueType)
+}
+
+func (Base32) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(base32.NewDecoder(base32.StdEncoding, r), ErrBase32WrongValueType)
+}
+
+func (b Base32) Reverse() Encoding {
+	return b
+}
+
+// ============================================================================
+// Base32Hex - RFC 4648 base32 with the extended hex alphabet, which keeps
+// the sort order of the encoded data
+// ============================================================================
+
+type Base32Hex struct{}
+
+func NewBase32Hex() *Base32Hex {
+	return new(Base32Hex)
+}
+
+func (b Base32Hex) String() string {
+	return reflectx.TypeName(b)
+}
+
+func (Base32Hex) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base32Hex) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base32Hex) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase32HexWrongValueType
+	}
+	return base32.HexEncoding.AppendEncode(dst, data), nil
+}
+
+func (Base32Hex) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := base32.HexEncoding.DecodeString(string(data))
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase32HexWrongValueType
+	}
+}
+
+func (Base32Hex) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(base32.NewEncoder(base32.HexEncoding, w), ErrBase32HexWrongValueType)
+}
+
+func (Base32Hex) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(base32.NewDecoder(base32.HexEncoding, r), ErrBase32HexWrongValueType)
+}
+
+func (b Base32Hex) Reverse() Encoding {
+	return b
+}
+
+// ============================================================================
+// Base32Raw - RFC 4648 base32 without padding, for DNS labels and tokens
+// ============================================================================
+
+var base32RawEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type Base32Raw struct{}
+
+func NewBase32Raw() *Base32Raw {
+	return new(Base32Raw)
+}
+
+func (b Base32Raw) String() string {
+	return reflectx.TypeName(b)
+}
+
+func (Base32Raw) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base32Raw) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base32Raw) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase32RawWrongValueType
+	}
+	return base32RawEncoding.AppendEncode(dst, data), nil
+}
+
+func (Base32Raw) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := base32RawEncoding.DecodeString(string(data))
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase32RawWrongValueType
+	}
+}
+
+func (Base32Raw) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(base32.NewEncoder(base32RawEncoding, w), ErrBase32RawWrongValueType)
+}
+
+func (Base32Raw) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(base32.NewDecoder(base32RawEncoding, r), ErrBase32RawWrongValueType)
+}
+
+func (b Base32Raw) Reverse() Encoding {
+	return b
+}
+
+// ============================================================================
+// Base32Crockford - Douglas Crockford's base32, unpadded and case
+// insensitive. Decoding reads I and L as 1, O as 0 and skips hyphens.
+// With WithCheckSymbol a check symbol is appended: the payload read as a
+// big-endian integer modulo 37, from the alphabet extended by "*~$=U".
+// ============================================================================
+
+const (
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	crockfordCheck    = crockfordAlphabet + "*~$=U"
+)
+
+var (
+	crockfordEncoding = base32.NewEncoding(crockfordAlphabet).WithPadding(base32.NoPadding)
+	crockfordSymbols  = newCrockfordSymbols()
+)
+
+// newCrockfordSymbols maps every accepted input byte to its check symbol
+// value, or -1.
+func newCrockfordSymbols() [256]int8 {
+	var symbols [256]int8
+	for i := range symbols {
+		symbols[i] = -1
+	}
+	for i := 0; i < len(crockfordCheck); i++ {
+		c := crockfordCheck[i]
+		symbols[c] = int8(i)
+		if c >= 'A' && c <= 'Z' {
+			symbols[c+'a'-'A'] = int8(i)
+		}
+	}
+	symbols['I'], symbols['i'] = 1, 1
+	symbols['L'], symbols['l'] = 1, 1
+	symbols['O'], symbols['o'] = 0, 0
+	return symbols
+}
+
+type Base32Crockford struct {
+	options
+}
+
+// NewBase32Crockford creates a Base32Crockford encoding, honoring WithName
+// and WithCheckSymbol.
+func NewBase32Crockford(opts ...Base32Option) *Base32Crockford {
+	return &Base32Crockford{
+		options: newOptions(opts, Base32Option.applyBase32),
+	}
+}
+
+func (b Base32Crockford) String() string {
+	return b.nameOr(reflectx.TypeName(b))
+}
+
+func (Base32Crockford) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base32Crockford) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (b Base32Crockford) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase32CrockfordWrongValueType
+	}
+	dst = crockfordEncoding.AppendEncode(dst, data)
+	if b.checkSymbol {
+		dst = append(dst, crockfordCheck[crockfordMod37(data)])
+	}
+	return dst, nil
+}
+
+func (b Base32Crockford) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := b.decode(data)
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase32CrockfordWrongValueType
+	}
+}
+
+func (b Base32Crockford) decode(data []byte) ([]byte, error) {
+	canonical := make([]byte, 0, len(data))
+	for _, c := range data {
+		if c == '-' {
+			continue
+		}
+		symbol := crockfordSymbols[c]
+		if symbol < 0 {
+			return nil, ErrBase32CrockfordInvalidData
+		}
+		canonical = append(canonical, crockfordCheck[symbol])
+	}
+
+	check := -1
+	if b.checkSymbol {
+		if len(canonical) == 0 {
+			return nil, ErrBase32CrockfordInvalidData
+		}
+		check = int(crockfordSymbols[canonical[len(canonical)-1]])
+		canonical = canonical[:len(canonical)-1]
+	}
+	for _, c := range canonical {
+		// Check symbols are only valid in the last position
+		if crockfordSymbols[c] >= 32 {
+			return nil, ErrBase32CrockfordInvalidData
+		}
+	}
+
+	switch len(canonical) % 8 {
+	case 1, 3, 6:
+		// No whole number of bytes ends there
+		return nil, ErrBase32CrockfordInvalidData
+	}
+	decoded := make([]byte, crockfordEncoding.DecodedLen(len(canonical)))
+	n, err := crockfordEncoding.Decode(decoded, canonical)
+	if err != nil {
+		return nil, ErrBase32CrockfordInvalidData
+	}
+	decoded = decoded[:n]
+	if check >= 0 && crockfordMod37(decoded) != check {
+		return nil, ErrBase32CrockfordCheckMismatch
+	}
+	return decoded, nil
+}
+
+func (b Base32Crockford) Reverse() Encoding {
+	return b
+}
+
+func crockfordMod37(data []byte) int {
+	mod := 0
+	for _, c := range data {
+		mod = (mod<<8 | int(c)) % 37
+	}
+	return mod
+}
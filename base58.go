@@ -0,0 +1,289 @@
+package encodingx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrBase58WrongValueType       = errors.New("encoding base58 converts on wrong type value")
+	ErrBase58FlickrWrongValueType = errors.New("encoding base58Flickr converts on wrong type value")
+	ErrBase58CheckWrongValueType  = errors.New("encoding base58Check converts on wrong type value")
+	ErrBase58InvalidData          = errors.New("encoding base58 invalid data")
+	ErrBase58CheckInvalidChecksum = errors.New("encoding base58Check invalid checksum")
+	ErrBase58CheckVersionMismatch = errors.New("encoding base58Check version mismatch")
+)
+
+var (
+	base58Bitcoin = newBase58Alphabet("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+	base58Flickr  = newBase58Alphabet("123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ")
+)
+
+func init() {
+	register(NewBase58())
+	register(NewBase58Flickr())
+	register(NewBase58Check())
+}
+
+// ============================================================================
+// Base58 - Bitcoin alphabet, no 0, O, I or l
+// Leading zero bytes are kept as leading '1' characters
+// ============================================================================
+
+type Base58 struct{}
+
+func NewBase58() *Base58 {
+	return new(Base58)
+}
+
+func (b Base58) String() string {
+	return reflectx.TypeName(b)
+}
+
+func (Base58) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base58) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base58) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase58WrongValueType
+	}
+	return base58Bitcoin.appendEncode(dst, data), nil
+}
+
+func (Base58) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := base58Bitcoin.decode(data)
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase58WrongValueType
+	}
+}
+
+func (b Base58) Reverse() Encoding {
+	return b
+}
+
+// ============================================================================
+// Base58Flickr - Flickr alphabet, lowercase letters before uppercase ones
+// ============================================================================
+
+type Base58Flickr struct{}
+
+func NewBase58Flickr() *Base58Flickr {
+	return new(Base58Flickr)
+}
+
+func (b Base58Flickr) String() string {
+	return reflectx.TypeName(b)
+}
+
+func (Base58Flickr) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base58Flickr) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base58Flickr) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase58FlickrWrongValueType
+	}
+	return base58Flickr.appendEncode(dst, data), nil
+}
+
+func (Base58Flickr) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := base58Flickr.decode(data)
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase58FlickrWrongValueType
+	}
+}
+
+func (b Base58Flickr) Reverse() Encoding {
+	return b
+}
+
+// ============================================================================
+// Base58Check - Bitcoin Base58 of a version byte, the payload and a checksum
+// Format: [1 byte version] + [payload] + [4 bytes of SHA256(SHA256(version + payload))]
+// ============================================================================
+
+const base58ChecksumLen = 4
+
+type Base58Check struct {
+	options
+}
+
+// NewBase58Check creates a Base58Check encoding, honoring WithName and
+// WithVersion. Unmarshal rejects data carrying another version byte.
+func NewBase58Check(opts ...Base58Option) *Base58Check {
+	return &Base58Check{
+		options: newOptions(opts, Base58Option.applyBase58),
+	}
+}
+
+func (b Base58Check) String() string {
+	return b.nameOr(reflectx.TypeName(b))
+}
+
+func (Base58Check) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base58Check) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (b Base58Check) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase58CheckWrongValueType
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf = append(*buf, b.version)
+	*buf = append(*buf, data...)
+	checksum := base58Checksum(*buf)
+	*buf = append(*buf, checksum[:]...)
+	return base58Bitcoin.appendEncode(dst, *buf), nil
+}
+
+func (b Base58Check) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := base58Bitcoin.decode(data)
+		if err != nil {
+			return err
+		}
+		if len(decoded) < 1+base58ChecksumLen {
+			return ErrBase58InvalidData
+		}
+		body := decoded[:len(decoded)-base58ChecksumLen]
+		checksum := base58Checksum(body)
+		if !bytes.Equal(checksum[:], decoded[len(body):]) {
+			return ErrBase58CheckInvalidChecksum
+		}
+		if body[0] != b.version {
+			return ErrBase58CheckVersionMismatch
+		}
+		v.Data = body[1:]
+		return nil
+	default:
+		return ErrBase58CheckWrongValueType
+	}
+}
+
+func (b Base58Check) Reverse() Encoding {
+	return b
+}
+
+func base58Checksum(data []byte) [base58ChecksumLen]byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return [base58ChecksumLen]byte(second[:base58ChecksumLen])
+}
+
+// base58Alphabet converts between bytes and one base58 alphabet.
+type base58Alphabet struct {
+	symbols string
+	digits  [256]int8
+}
+
+func newBase58Alphabet(alphabet string) *base58Alphabet {
+	a := &base58Alphabet{symbols: alphabet}
+	for i := range a.digits {
+		a.digits[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		a.digits[alphabet[i]] = int8(i)
+	}
+	return a
+}
+
+func (a *base58Alphabet) appendEncode(dst, src []byte) []byte {
+	zeros := 0
+	for zeros < len(src) && src[zeros] == 0 {
+		zeros++
+	}
+
+	// log(256) / log(58) is below 1.38
+	digits := make([]byte, (len(src)-zeros)*138/100+1)
+	high := len(digits) - 1
+	for _, b := range src[zeros:] {
+		carry := int(b)
+		j := len(digits) - 1
+		for ; j > high || carry != 0; j-- {
+			carry += int(digits[j]) << 8
+			digits[j] = byte(carry % 58)
+			carry /= 58
+		}
+		high = j
+	}
+
+	start := 0
+	for start < len(digits) && digits[start] == 0 {
+		start++
+	}
+	for i := 0; i < zeros; i++ {
+		dst = append(dst, a.symbols[0])
+	}
+	for _, d := range digits[start:] {
+		dst = append(dst, a.symbols[d])
+	}
+	return dst
+}
+
+func (a *base58Alphabet) decode(src []byte) ([]byte, error) {
+	zeros := 0
+	for zeros < len(src) && src[zeros] == a.symbols[0] {
+		zeros++
+	}
+
+	// log(58) / log(256) is below 0.733
+	out := make([]byte, (len(src)-zeros)*733/1000+1)
+	high := len(out) - 1
+	for _, c := range src[zeros:] {
+		digit := a.digits[c]
+		if digit < 0 {
+			return nil, ErrBase58InvalidData
+		}
+		carry := int(digit)
+		j := len(out) - 1
+		for ; j > high || carry != 0; j-- {
+			carry += int(out[j]) * 58
+			out[j] = byte(carry)
+			carry >>= 8
+		}
+		high = j
+	}
+
+	start := 0
+	for start < len(out) && out[start] == 0 {
+		start++
+	}
+	decoded := make([]byte, zeros+len(out)-start)
+	copy(decoded[zeros:], out[start:])
+	return decoded, nil
+}
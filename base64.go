@@ -3,53 +3,60 @@ package encodingx
 import (
 	"encoding/base64"
 	"errors"
+	"io"
+	"slices"
 
 	"github.com/aura-studio/reflectx"
 )
 
 var (
-	ErrBase64WrongValueType    = errors.New("encoding base64 converts on wrong type value")
-	ErrBase64URLWrongValueType = errors.New("encoding base64URL converts on wrong type value")
+	ErrBase64WrongValueType       = errors.New("encoding base64 converts on wrong type value")
+	ErrBase64URLWrongValueType    = errors.New("encoding base64URL converts on wrong type value")
+	ErrBase64RawWrongValueType    = errors.New("encoding base64Raw converts on wrong type value")
+	ErrBase64URLRawWrongValueType = errors.New("encoding base64URLRaw converts on wrong type value")
+	ErrBase64MIMEWrongValueType   = errors.New("encoding base64MIME converts on wrong type value")
 )
 
-type Base64 struct{}
+type Base64 struct {
+	options
+}
 
 func init() {
 	register(NewBase64())
+	register(NewBase64(WithName("Base64Lenient"), WithLenient()))
 }
 
-func NewBase64() *Base64 {
-	return new(Base64)
+// NewBase64 creates a Base64 encoding, honoring WithName and WithLenient.
+func NewBase64(opts ...Base64Option) *Base64 {
+	return &Base64{
+		options: newOptions(opts, Base64Option.applyBase64),
+	}
 }
 
 func (b Base64) String() string {
-	return reflectx.TypeName(b)
+	return b.nameOr(reflectx.TypeName(b))
 }
 
 func (Base64) Style() EncodingStyleType {
 	return EncodingStyleBytes
 }
 
-func (Base64) Marshal(v interface{}) ([]byte, error) {
-	switch v := v.(type) {
-	case []byte:
-		s := base64.StdEncoding.EncodeToString(v)
-		return []byte(s), nil
-	case Bytes:
-		s := base64.StdEncoding.EncodeToString(v.Data)
-		return []byte(s), nil
-	case *Bytes:
-		s := base64.StdEncoding.EncodeToString(v.Data)
-		return []byte(s), nil
-	default:
+func (b Base64) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base64) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
 		return nil, ErrBase64WrongValueType
 	}
+	return base64.StdEncoding.AppendEncode(dst, data), nil
 }
 
-func (Base64) Unmarshal(data []byte, v interface{}) error {
+func (b Base64) Unmarshal(data []byte, v interface{}) error {
 	switch v := v.(type) {
 	case *Bytes:
-		s, err := base64.StdEncoding.DecodeString(string(data))
+		s, err := decodeBase64(base64.StdEncoding, data, b.lenient)
 		if err != nil {
 			return err
 		}
@@ -60,48 +67,58 @@ func (Base64) Unmarshal(data []byte, v interface{}) error {
 	}
 }
 
+func (Base64) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(base64.NewEncoder(base64.StdEncoding, w), ErrBase64WrongValueType)
+}
+
+func (b Base64) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(newBase64Decoder(base64.StdEncoding, r, b.lenient), ErrBase64WrongValueType)
+}
+
 func (b Base64) Reverse() Encoding {
 	return b
 }
 
-type Base64URL struct{}
+type Base64URL struct {
+	options
+}
 
 func init() {
 	register(NewBase64URL())
+	register(NewBase64URL(WithName("Base64URLLenient"), WithLenient()))
 }
 
-func NewBase64URL() *Base64URL {
-	return new(Base64URL)
+// NewBase64URL creates a Base64URL encoding, honoring WithName and WithLenient.
+func NewBase64URL(opts ...Base64Option) *Base64URL {
+	return &Base64URL{
+		options: newOptions(opts, Base64Option.applyBase64),
+	}
 }
 
 func (b Base64URL) String() string {
-	return reflectx.TypeName(b)
+	return b.nameOr(reflectx.TypeName(b))
 }
 
 func (Base64URL) Style() EncodingStyleType {
 	return EncodingStyleBytes
 }
 
-func (Base64URL) Marshal(v interface{}) ([]byte, error) {
-	switch v := v.(type) {
-	case []byte:
-		s := base64.URLEncoding.EncodeToString(v)
-		return []byte(s), nil
-	case Bytes:
-		s := base64.URLEncoding.EncodeToString(v.Data)
-		return []byte(s), nil
-	case *Bytes:
-		s := base64.URLEncoding.EncodeToString(v.Data)
-		return []byte(s), nil
-	default:
+func (b Base64URL) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base64URL) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
 		return nil, ErrBase64URLWrongValueType
 	}
+	return base64.URLEncoding.AppendEncode(dst, data), nil
 }
 
-func (Base64URL) Unmarshal(data []byte, v interface{}) error {
+func (b Base64URL) Unmarshal(data []byte, v interface{}) error {
 	switch v := v.(type) {
 	case *Bytes:
-		s, err := base64.URLEncoding.DecodeString(string(data))
+		s, err := decodeBase64(base64.URLEncoding, data, b.lenient)
 		if err != nil {
 			return err
 		}
@@ -112,6 +129,336 @@ func (Base64URL) Unmarshal(data []byte, v interface{}) error {
 	}
 }
 
+func (Base64URL) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(base64.NewEncoder(base64.URLEncoding, w), ErrBase64URLWrongValueType)
+}
+
+func (b Base64URL) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(newBase64Decoder(base64.URLEncoding, r, b.lenient), ErrBase64URLWrongValueType)
+}
+
 func (b Base64URL) Reverse() Encoding {
 	return b
 }
+
+// Base64Raw is Base64 without padding.
+type Base64Raw struct {
+	options
+}
+
+func init() {
+	register(NewBase64Raw())
+}
+
+// NewBase64Raw creates a Base64Raw encoding, honoring WithName and WithLenient.
+func NewBase64Raw(opts ...Base64Option) *Base64Raw {
+	return &Base64Raw{
+		options: newOptions(opts, Base64Option.applyBase64),
+	}
+}
+
+func (b Base64Raw) String() string {
+	return b.nameOr(reflectx.TypeName(b))
+}
+
+func (Base64Raw) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base64Raw) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base64Raw) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase64RawWrongValueType
+	}
+	return base64.RawStdEncoding.AppendEncode(dst, data), nil
+}
+
+func (b Base64Raw) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := decodeBase64(base64.RawStdEncoding, data, b.lenient)
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase64RawWrongValueType
+	}
+}
+
+func (Base64Raw) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(base64.NewEncoder(base64.RawStdEncoding, w), ErrBase64RawWrongValueType)
+}
+
+func (b Base64Raw) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(newBase64Decoder(base64.RawStdEncoding, r, b.lenient), ErrBase64RawWrongValueType)
+}
+
+func (b Base64Raw) Reverse() Encoding {
+	return b
+}
+
+// Base64URLRaw is Base64URL without padding, as used by JWT and WebAuthn.
+type Base64URLRaw struct {
+	options
+}
+
+func init() {
+	register(NewBase64URLRaw())
+}
+
+// NewBase64URLRaw creates a Base64URLRaw encoding, honoring WithName and
+// WithLenient.
+func NewBase64URLRaw(opts ...Base64Option) *Base64URLRaw {
+	return &Base64URLRaw{
+		options: newOptions(opts, Base64Option.applyBase64),
+	}
+}
+
+func (b Base64URLRaw) String() string {
+	return b.nameOr(reflectx.TypeName(b))
+}
+
+func (Base64URLRaw) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base64URLRaw) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base64URLRaw) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase64URLRawWrongValueType
+	}
+	return base64.RawURLEncoding.AppendEncode(dst, data), nil
+}
+
+func (b Base64URLRaw) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := decodeBase64(base64.RawURLEncoding, data, b.lenient)
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase64URLRawWrongValueType
+	}
+}
+
+func (Base64URLRaw) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(base64.NewEncoder(base64.RawURLEncoding, w), ErrBase64URLRawWrongValueType)
+}
+
+func (b Base64URLRaw) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(newBase64Decoder(base64.RawURLEncoding, r, b.lenient), ErrBase64URLRawWrongValueType)
+}
+
+func (b Base64URLRaw) Reverse() Encoding {
+	return b
+}
+
+// base64MIMELineLen is the line length limit of RFC 2045.
+const base64MIMELineLen = 76
+
+// Base64MIME is Base64 broken into CRLF separated lines of 76 characters,
+// as in MIME bodies. Unmarshal is always lenient.
+type Base64MIME struct {
+	options
+}
+
+func init() {
+	register(NewBase64MIME())
+}
+
+// NewBase64MIME creates a Base64MIME encoding, honoring WithName.
+func NewBase64MIME(opts ...Option) *Base64MIME {
+	return &Base64MIME{
+		options: newOptions(opts, Option.apply),
+	}
+}
+
+func (b Base64MIME) String() string {
+	return b.nameOr(reflectx.TypeName(b))
+}
+
+func (Base64MIME) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base64MIME) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base64MIME) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase64MIMEWrongValueType
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf = base64.StdEncoding.AppendEncode(*buf, data)
+	encoded := *buf
+	dst = slices.Grow(dst, len(encoded)+len(encoded)/base64MIMELineLen*2)
+	for len(encoded) > base64MIMELineLen {
+		dst = append(dst, encoded[:base64MIMELineLen]...)
+		dst = append(dst, '\r', '\n')
+		encoded = encoded[base64MIMELineLen:]
+	}
+	return append(dst, encoded...), nil
+}
+
+func (Base64MIME) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := decodeBase64(base64.StdEncoding, data, true)
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase64MIMEWrongValueType
+	}
+}
+
+func (Base64MIME) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(base64.NewEncoder(base64.StdEncoding, &lineWriter{w: w}), ErrBase64MIMEWrongValueType)
+}
+
+func (Base64MIME) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(newBase64Decoder(base64.StdEncoding, r, true), ErrBase64MIMEWrongValueType)
+}
+
+func (b Base64MIME) Reverse() Encoding {
+	return b
+}
+
+// decodeBase64 decodes data with enc. When lenient, whitespace is skipped
+// and padding is optional, but may only end the input.
+func decodeBase64(enc *base64.Encoding, data []byte, lenient bool) ([]byte, error) {
+	if !lenient {
+		return enc.DecodeString(string(data))
+	}
+	var lr base64LenientReader
+	stripped := make([]byte, 0, len(data))
+	for _, c := range data {
+		if lr.keep(c) {
+			stripped = append(stripped, c)
+		}
+		if lr.err != nil {
+			return nil, lr.err
+		}
+	}
+	if err := lr.finish(); err != nil {
+		return nil, err
+	}
+	return enc.WithPadding(base64.NoPadding).DecodeString(string(stripped))
+}
+
+func newBase64Decoder(enc *base64.Encoding, r io.Reader, lenient bool) io.Reader {
+	if !lenient {
+		return base64.NewDecoder(enc, r)
+	}
+	return base64.NewDecoder(enc.WithPadding(base64.NoPadding), &base64LenientReader{r: r})
+}
+
+// base64LenientReader drops whitespace and the padding ending r. Padding
+// other than that implied by the length of the data, and data after padding,
+// fail with a base64.CorruptInputError.
+type base64LenientReader struct {
+	r       io.Reader
+	offset  int64
+	data    int64
+	padding int64
+	err     error
+}
+
+func (lr *base64LenientReader) Read(p []byte) (int, error) {
+	for lr.err == nil {
+		n, err := lr.r.Read(p)
+		kept := 0
+		for _, c := range p[:n] {
+			if lr.keep(c) {
+				p[kept] = c
+				kept++
+			}
+			if lr.err != nil {
+				return kept, lr.err
+			}
+		}
+		if err == io.EOF && lr.finish() != nil {
+			return kept, lr.err
+		}
+		if kept > 0 || err != nil {
+			return kept, err
+		}
+	}
+	return 0, lr.err
+}
+
+// keep reports whether c is data to pass on, setting err for excess padding
+// and for data after padding.
+func (lr *base64LenientReader) keep(c byte) bool {
+	offset := lr.offset
+	lr.offset++
+	switch {
+	case isSpace(c):
+		return false
+	case c == '=':
+		lr.padding++
+		if lr.data%4 < 2 || lr.padding > 4-lr.data%4 {
+			lr.err = base64.CorruptInputError(offset)
+		}
+		return false
+	case lr.padding > 0:
+		lr.err = base64.CorruptInputError(offset)
+		return false
+	}
+	lr.data++
+	return true
+}
+
+// finish sets err when the input ended part way through its padding.
+func (lr *base64LenientReader) finish() error {
+	if lr.padding > 0 && lr.padding != 4-lr.data%4 {
+		lr.err = base64.CorruptInputError(lr.offset)
+	}
+	return lr.err
+}
+
+// lineWriter breaks what is written through it into CRLF separated lines of
+// base64MIMELineLen bytes.
+type lineWriter struct {
+	w      io.Writer
+	column int
+}
+
+func (lw *lineWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if lw.column == base64MIMELineLen {
+			if _, err := lw.w.Write([]byte("\r\n")); err != nil {
+				return written, err
+			}
+			lw.column = 0
+		}
+		chunk := p[:min(len(p), base64MIMELineLen-lw.column)]
+		n, err := lw.w.Write(chunk)
+		written += n
+		lw.column += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
@@ -0,0 +1,317 @@
+package encodingx
+
+import (
+	"bytes"
+	"encoding/ascii85"
+	"errors"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrAscii85WrongValueType = errors.New("encoding ascii85 converts on wrong type value")
+	ErrAscii85InvalidFrame   = errors.New("encoding ascii85 missing <~ ~> delimiters")
+	ErrAscii85InvalidData    = errors.New("encoding ascii85 invalid data")
+	ErrZ85WrongValueType     = errors.New("encoding Z85 converts on wrong type value")
+	ErrZ85Unaligned          = errors.New("encoding Z85 data length is not a multiple of 4")
+	ErrZ85InvalidData        = errors.New("encoding Z85 invalid data")
+	ErrBase85WrongValueType  = errors.New("encoding base85 converts on wrong type value")
+	ErrBase85InvalidData     = errors.New("encoding base85 invalid data")
+)
+
+var (
+	ascii85Alphabet = newBase85Alphabet("!\"#$%&'()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\\]^_`abcdefghijklmnopqrstu")
+	z85Alphabet     = newBase85Alphabet("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ.-:+=^!/*?&<>()[]{}@%$#")
+	base85RFC1924   = newBase85Alphabet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz!#$%&()*+-;<=>?@^_`{|}~")
+)
+
+func init() {
+	register(NewAscii85())
+	register(NewZ85())
+	register(NewZ85(WithName("Z85Padded"), WithPadding()))
+	register(NewBase85())
+}
+
+// ============================================================================
+// Ascii85 - Adobe ascii85 as found in PostScript and PDF
+// Format: "<~" + [ascii85 data, z for four zero bytes] + "~>"
+// Unmarshal skips whitespace and accepts data without the leading "<~",
+// as PDF streams store it.
+// ============================================================================
+
+type Ascii85 struct{}
+
+func NewAscii85() *Ascii85 {
+	return new(Ascii85)
+}
+
+func (a Ascii85) String() string {
+	return reflectx.TypeName(a)
+}
+
+func (Ascii85) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (a Ascii85) Marshal(v interface{}) ([]byte, error) {
+	return a.AppendMarshal(nil, v)
+}
+
+func (Ascii85) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrAscii85WrongValueType
+	}
+	start := len(dst)
+	dst = append(dst, "<~"...)
+	dst = append(dst, make([]byte, ascii85.MaxEncodedLen(len(data)))...)
+	n := ascii85.Encode(dst[start+2:], data)
+	dst = append(dst[:start+2+n], "~>"...)
+	return dst, nil
+}
+
+func (Ascii85) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		data = bytes.TrimSpace(data)
+		data = bytes.TrimPrefix(data, []byte("<~"))
+		if !bytes.HasSuffix(data, []byte("~>")) {
+			return ErrAscii85InvalidFrame
+		}
+		data = data[:len(data)-2]
+
+		// Expand z and drop whitespace so only plain groups remain
+		groups := make([]byte, 0, len(data))
+		for _, c := range data {
+			switch {
+			case isSpace(c):
+			case c == 'z':
+				if len(groups)%5 != 0 {
+					return ErrAscii85InvalidData
+				}
+				groups = append(groups, "!!!!!"...)
+			default:
+				groups = append(groups, c)
+			}
+		}
+		decoded, ok := ascii85Alphabet.decode(groups)
+		if !ok {
+			return ErrAscii85InvalidData
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrAscii85WrongValueType
+	}
+}
+
+func (a Ascii85) Reverse() Encoding {
+	return a
+}
+
+// ============================================================================
+// Z85 - ZeroMQ base85 (RFC 32), for data whose length is a multiple of 4
+// With WithPadding other lengths are accepted: the data is padded with
+// zero bytes to a multiple of 4 and one more character gives the number of
+// padding bytes, '0' to '3'.
+// ============================================================================
+
+type Z85 struct {
+	options
+}
+
+// NewZ85 creates a Z85 encoding, honoring WithName and WithPadding.
+func NewZ85(opts ...Base85Option) *Z85 {
+	return &Z85{
+		options: newOptions(opts, Base85Option.applyBase85),
+	}
+}
+
+func (z Z85) String() string {
+	return z.nameOr(reflectx.TypeName(z))
+}
+
+func (Z85) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (z Z85) Marshal(v interface{}) ([]byte, error) {
+	return z.AppendMarshal(nil, v)
+}
+
+func (z Z85) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrZ85WrongValueType
+	}
+	if !z.padding {
+		if len(data)%4 != 0 {
+			return nil, ErrZ85Unaligned
+		}
+		return z85Alphabet.appendEncode(dst, data), nil
+	}
+
+	whole := len(data) &^ 3
+	dst = z85Alphabet.appendEncode(dst, data[:whole])
+	padding := 0
+	if tail := data[whole:]; len(tail) > 0 {
+		padding = 4 - len(tail)
+		var group [4]byte
+		copy(group[:], tail)
+		dst = z85Alphabet.appendEncode(dst, group[:])
+	}
+	return append(dst, z85Alphabet.symbols[padding]), nil
+}
+
+func (z Z85) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		padding := 0
+		if z.padding {
+			if len(data)%5 != 1 {
+				return ErrZ85InvalidData
+			}
+			padding = int(z85Alphabet.digits[data[len(data)-1]])
+			if padding < 0 || padding > 3 || (padding > 0 && len(data) == 1) {
+				return ErrZ85InvalidData
+			}
+			data = data[:len(data)-1]
+		} else if len(data)%5 != 0 {
+			return ErrZ85InvalidData
+		}
+		decoded, ok := z85Alphabet.decode(data)
+		if !ok {
+			return ErrZ85InvalidData
+		}
+		v.Data = decoded[:len(decoded)-padding]
+		return nil
+	default:
+		return ErrZ85WrongValueType
+	}
+}
+
+func (z Z85) Reverse() Encoding {
+	return z
+}
+
+// ============================================================================
+// Base85 - RFC 1924 alphabet as used by git binary patches
+// A trailing group of n < 4 bytes is written as n + 1 characters.
+// ============================================================================
+
+type Base85 struct{}
+
+func NewBase85() *Base85 {
+	return new(Base85)
+}
+
+func (b Base85) String() string {
+	return reflectx.TypeName(b)
+}
+
+func (Base85) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Base85) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (Base85) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBase85WrongValueType
+	}
+	return base85RFC1924.appendEncode(dst, data), nil
+}
+
+func (Base85) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, ok := base85RFC1924.decode(data)
+		if !ok {
+			return ErrBase85InvalidData
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrBase85WrongValueType
+	}
+}
+
+func (b Base85) Reverse() Encoding {
+	return b
+}
+
+// base85Alphabet converts between bytes and one base85 alphabet, four bytes
+// to five characters. A trailing group of n < 4 bytes is padded with zero
+// bytes and cut to n + 1 characters, as done by Ascii85 and git.
+type base85Alphabet struct {
+	symbols string
+	digits  [256]int8
+}
+
+func newBase85Alphabet(alphabet string) *base85Alphabet {
+	a := &base85Alphabet{symbols: alphabet}
+	for i := range a.digits {
+		a.digits[i] = -1
+	}
+	for i := 0; i < len(alphabet); i++ {
+		a.digits[alphabet[i]] = int8(i)
+	}
+	return a
+}
+
+func (a *base85Alphabet) appendEncode(dst, src []byte) []byte {
+	for len(src) > 0 {
+		var group [4]byte
+		n := copy(group[:], src)
+		src = src[n:]
+		value := uint32(group[0])<<24 | uint32(group[1])<<16 | uint32(group[2])<<8 | uint32(group[3])
+		var chars [5]byte
+		for i := 4; i >= 0; i-- {
+			chars[i] = a.symbols[value%85]
+			value /= 85
+		}
+		dst = append(dst, chars[:n+1]...)
+	}
+	return dst
+}
+
+// decode fails on characters outside the alphabet, groups above 2^32-1
+// and a trailing group of a single character.
+func (a *base85Alphabet) decode(src []byte) ([]byte, bool) {
+	if len(src)%5 == 1 {
+		return nil, false
+	}
+	decoded := make([]byte, 0, (len(src)+4)/5*4)
+	for len(src) > 0 {
+		n := min(len(src), 5)
+		var value uint64
+		for i := 0; i < 5; i++ {
+			digit := int8(84)
+			if i < n {
+				digit = a.digits[src[i]]
+				if digit < 0 {
+					return nil, false
+				}
+			}
+			value = value*85 + uint64(digit)
+		}
+		if value > 0xFFFFFFFF {
+			return nil, false
+		}
+		src = src[n:]
+		group := [4]byte{byte(value >> 24), byte(value >> 16), byte(value >> 8), byte(value)}
+		decoded = append(decoded, group[:n-1]...)
+	}
+	return decoded, true
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\v', '\f', '\r', 0:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package encodingx
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/andybalholm/brotli"
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrBrotliWrongValueType = errors.New("encoding brotli converts on wrong type value")
+	ErrBrotliInvalidLevel   = errors.New("encoding brotli invalid compression level")
+)
+
+func init() {
+	register(NewBrotli())
+}
+
+// ============================================================================
+// Brotli - RFC 7932 brotli stream
+// WithLevel takes brotli.BestSpeed (0) to brotli.BestCompression (11).
+// ============================================================================
+
+type Brotli struct {
+	options
+}
+
+// NewBrotli creates a Brotli encoding, honoring WithName, WithLevel and the
+// decompression limits.
+func NewBrotli(opts ...CompressOption) *Brotli {
+	return &Brotli{
+		options: newOptions(opts, CompressOption.applyCompress),
+	}
+}
+
+func (b Brotli) String() string {
+	return b.nameOr(reflectx.TypeName(b))
+}
+
+func (Brotli) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (b Brotli) Marshal(v interface{}) ([]byte, error) {
+	return b.AppendMarshal(nil, v)
+}
+
+func (b Brotli) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	return b.appendMarshal(context.Background(), dst, v)
+}
+
+// MarshalContext is like Marshal but stops compressing once ctx is done.
+func (b Brotli) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	return b.appendMarshal(ctx, nil, v)
+}
+
+func (b Brotli) appendMarshal(ctx context.Context, dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrBrotliWrongValueType
+	}
+	return brotliFormat.appendCompress(ctx, dst, data, b.levelOr(brotli.DefaultCompression), nil)
+}
+
+func (b Brotli) Unmarshal(data []byte, v interface{}) error {
+	return b.UnmarshalContext(context.Background(), data, v)
+}
+
+// UnmarshalContext is like Unmarshal but stops decompressing once ctx is
+// done.
+func (b Brotli) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decompressed, err := brotliFormat.decompress(ctx, data, nil, b.decompressLimits())
+		if err != nil {
+			return err
+		}
+		v.Data = decompressed
+		return nil
+	default:
+		return ErrBrotliWrongValueType
+	}
+}
+
+func (b Brotli) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(brotliFormat.newStreamWriter(w, b.levelOr(brotli.DefaultCompression), nil), ErrBrotliWrongValueType)
+}
+
+func (b Brotli) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(brotliFormat.newStreamReader(r, nil, b.decompressLimits()), ErrBrotliWrongValueType)
+}
+
+func (b Brotli) Reverse() Encoding {
+	return b
+}
+
+var brotliFormat = &compressFormat{
+	newWriter: func(w io.Writer, level int, _ []byte) (compressWriter, error) {
+		if level < brotli.BestSpeed || level > brotli.BestCompression {
+			return nil, ErrBrotliInvalidLevel
+		}
+		return brotli.NewWriterLevel(w, level), nil
+	},
+	newReader: func(r io.Reader, _ []byte) (io.ReadCloser, error) {
+		return io.NopCloser(brotli.NewReader(r)), nil
+	},
+}
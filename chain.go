@@ -1,7 +1,9 @@
 package encodingx
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/aura-studio/magic"
@@ -9,18 +11,90 @@ import (
 
 var (
 	ErrWrongEncodingStyle = errors.New("wrong encoding style is found in chain")
+	ErrChainNotMirrored   = errors.New("encoding chain decoder does not mirror encoder")
 )
 
+type ChainDirection int
+
+const (
+	ChainMarshal ChainDirection = iota
+	ChainUnmarshal
+)
+
+var chainDirectionName = map[ChainDirection]string{
+	ChainMarshal:   "marshal",
+	ChainUnmarshal: "unmarshal",
+}
+
+func (d ChainDirection) String() string {
+	if s, ok := chainDirectionName[d]; ok {
+		return s
+	}
+	return fmt.Sprintf("chainDirectionName=%d?", int(d))
+}
+
+// ChainError reports which stage of a chain failed. Stage indexes the
+// encoder list for ChainMarshal and the decoder list for ChainUnmarshal.
+// The cause stays reachable through errors.Is and errors.As.
+type ChainError struct {
+	Stage     int
+	Name      string
+	Direction ChainDirection
+	Err       error
+}
+
+func newChainError(direction ChainDirection, stage int, name string, err error) *ChainError {
+	return &ChainError{
+		Stage:     stage,
+		Name:      name,
+		Direction: direction,
+		Err:       err,
+	}
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("encoding chain %s stage %d %q: %v", e.Direction, e.Stage, e.Name, e.Err)
+}
+
+func (e *ChainError) Unwrap() error {
+	return e.Err
+}
+
 type ChainEncoding struct {
-	encoder []string
-	decoder []string
+	encoder  []string
+	decoder  []string
+	registry *Registry
+	budget   int64
 }
 
+// NewChainEncoding creates a chain resolving its stages in the default registry.
 func NewChainEncoding(encoder, decoder []string) *ChainEncoding {
+	return defaultRegistry.NewChainEncoding(encoder, decoder)
+}
+
+// NewChainEncoding creates a chain resolving its stages in r.
+func (r *Registry) NewChainEncoding(encoder, decoder []string) *ChainEncoding {
 	return &ChainEncoding{
-		encoder: encoder,
-		decoder: decoder,
+		encoder:  encoder,
+		decoder:  decoder,
+		registry: r,
+	}
+}
+
+// NewChainEncodingChecked is like NewChainEncoding but returns the chain
+// only if it passes Validate.
+func NewChainEncodingChecked(encoder, decoder []string) (*ChainEncoding, error) {
+	return defaultRegistry.NewChainEncodingChecked(encoder, decoder)
+}
+
+// NewChainEncodingChecked is like NewChainEncoding but returns the chain
+// only if it passes Validate.
+func (r *Registry) NewChainEncodingChecked(encoder, decoder []string) (*ChainEncoding, error) {
+	c := r.NewChainEncoding(encoder, decoder)
+	if err := c.Validate(); err != nil {
+		return nil, err
 	}
+	return c, nil
 }
 
 var empty *ChainEncoding = NewChainEncoding([]string{"Lazy"}, []string{"Lazy"})
@@ -50,14 +124,68 @@ func (c ChainEncoding) String() string {
 	return builder.String()
 }
 
+// Registry returns the registry the chain resolves its stages in.
+func (c ChainEncoding) Registry() *Registry {
+	if c.registry == nil {
+		return defaultRegistry
+	}
+	return c.registry
+}
+
+// WithByteBudget returns a copy of c whose Unmarshal and Decoder fail with
+// ErrDecompressedTooLarge once the stages before the last have yielded
+// more than n bytes together. Decompressing stages are held to it while
+// they inflate, so a nest of compressed payloads is stopped early. A
+// budget of 0 or less removes it.
+func (c ChainEncoding) WithByteBudget(n int64) *ChainEncoding {
+	c.budget = n
+	return &c
+}
+
+// Validate checks up front what Marshal and Unmarshal would otherwise only
+// discover stage by stage: every name resolves, only the first encoder and
+// the last decoder are struct style, and the decoder is the encoder reversed.
+func (c ChainEncoding) Validate() error {
+	registry := c.Registry()
+	for index, name := range c.encoder {
+		encoding, err := registry.Lookup(name)
+		if err != nil {
+			return newChainError(ChainMarshal, index, name, err)
+		}
+		if index > 0 && encoding.Style() == EncodingStyleStruct {
+			return newChainError(ChainMarshal, index, name, ErrWrongEncodingStyle)
+		}
+	}
+	for index, name := range c.decoder {
+		encoding, err := registry.Lookup(name)
+		if err != nil {
+			return newChainError(ChainUnmarshal, index, name, err)
+		}
+		if index < len(c.decoder)-1 && encoding.Style() == EncodingStyleStruct {
+			return newChainError(ChainUnmarshal, index, name, ErrWrongEncodingStyle)
+		}
+	}
+	if len(c.encoder) != len(c.decoder) {
+		return fmt.Errorf("%w: %d encoder stages, %d decoder stages", ErrChainNotMirrored, len(c.encoder), len(c.decoder))
+	}
+	for index, name := range c.decoder {
+		if expected := c.encoder[len(c.encoder)-1-index]; name != expected {
+			return newChainError(ChainUnmarshal, index, name, fmt.Errorf("%w, expected %q", ErrChainNotMirrored, expected))
+		}
+	}
+	return nil
+}
+
 func (c ChainEncoding) Style() EncodingStyleType {
 	return EncodingStyleMix
 }
 
 func (c ChainEncoding) Reverse() Encoding {
 	re := ChainEncoding{
-		encoder: make([]string, len(c.decoder)),
-		decoder: make([]string, len(c.encoder)),
+		encoder:  make([]string, len(c.decoder)),
+		decoder:  make([]string, len(c.encoder)),
+		registry: c.registry,
+		budget:   c.budget,
 	}
 	lenDecoder := len(c.decoder)
 	for index := 0; index < lenDecoder; index++ {
@@ -71,52 +199,128 @@ func (c ChainEncoding) Reverse() Encoding {
 }
 
 func (c ChainEncoding) Marshal(v interface{}) ([]byte, error) {
+	return c.MarshalContext(context.Background(), v)
+}
+
+func (c ChainEncoding) Unmarshal(data []byte, v interface{}) error {
+	return c.UnmarshalContext(context.Background(), data, v)
+}
+
+// MarshalContext runs v through the encoder stages, checking ctx before
+// each of them and handing it to stages that are ContextEncodings.
+// Otherwise, stages implementing AppendMarshaler write into pooled buffers
+// that are recycled as soon as the next such stage has consumed them; the
+// last stage always allocates the returned slice.
+func (c ChainEncoding) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
 	var data []byte
+	var pooled *[]byte // pooled buffer data currently lives in
+	defer func() {
+		if pooled != nil {
+			putBuffer(pooled)
+		}
+	}()
+	cancelable := ctx.Done() != nil
+	registry := c.Registry()
 	for index, name := range c.encoder {
-		encoding, err := localEncoding(name)
+		if err := ctx.Err(); err != nil {
+			return nil, newChainError(ChainMarshal, index, name, err)
+		}
+		encoding, err := registry.Lookup(name)
 		if err != nil {
-			return nil, err
+			return nil, newChainError(ChainMarshal, index, name, err)
 		}
 		if index == 0 {
-			data, err = encoding.Marshal(v)
+			data, err = MarshalContext(ctx, encoding, v)
 			if err != nil {
-				return nil, err
+				return nil, newChainError(ChainMarshal, index, name, err)
 			}
 		} else {
 			if encoding.Style() == EncodingStyleStruct {
-				return nil, ErrWrongEncodingStyle
+				return nil, newChainError(ChainMarshal, index, name, ErrWrongEncodingStyle)
 			}
-			data, err = encoding.Marshal(data)
+			_, contextual := encoding.(ContextEncoding)
+			if appender, ok := encoding.(AppendMarshaler); ok && !(cancelable && contextual) {
+				var buf *[]byte
+				var dst []byte
+				if index < len(c.encoder)-1 {
+					buf = getBuffer()
+					dst = *buf
+				}
+				out, err := appender.AppendMarshal(dst, data)
+				if err != nil {
+					if buf != nil {
+						putBuffer(buf)
+					}
+					return nil, newChainError(ChainMarshal, index, name, err)
+				}
+				if pooled != nil {
+					putBuffer(pooled)
+				}
+				if buf != nil {
+					*buf = out
+				}
+				pooled = buf
+				data = out
+				continue
+			}
+			data, err = MarshalContext(ctx, encoding, data)
 			if err != nil {
-				return nil, err
+				return nil, newChainError(ChainMarshal, index, name, err)
 			}
+			// The output may alias the pooled input, leave that buffer to
+			// the garbage collector
+			pooled = nil
 		}
 	}
 	return data, nil
 }
 
-func (c ChainEncoding) Unmarshal(data []byte, v interface{}) error {
+// UnmarshalContext runs data through the decoder stages, checking ctx
+// before each of them and handing it to stages that are ContextEncodings.
+func (c ChainEncoding) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
 	bytes := MakeBytes(nil)
+	budget, stageCtx := c.newByteBudget(), ctx
+	if budget != nil {
+		stageCtx = withByteBudget(ctx, budget)
+	}
+	registry := c.Registry()
 	for index, name := range c.decoder {
-		encoding, err := localEncoding(name)
+		if err := ctx.Err(); err != nil {
+			return newChainError(ChainUnmarshal, index, name, err)
+		}
+		encoding, err := registry.Lookup(name)
 		if err != nil {
-			return err
+			return newChainError(ChainUnmarshal, index, name, err)
 		}
 		if index < len(c.decoder)-1 {
 			if encoding.Style() == EncodingStyleStruct {
-				return ErrWrongEncodingStyle
+				return newChainError(ChainUnmarshal, index, name, ErrWrongEncodingStyle)
+			}
+			var spent int64
+			if budget != nil {
+				spent = budget.spent
+			}
+			err = UnmarshalContext(stageCtx, encoding, data, &bytes)
+			if err == nil && budget != nil {
+				err = budget.spendOutput(spent, len(bytes.Data))
 			}
-			err = encoding.Unmarshal(data, &bytes)
 			if err != nil {
-				return err
+				return newChainError(ChainUnmarshal, index, name, err)
 			}
 			data = bytes.Data
 		} else {
-			err = encoding.Unmarshal(data, v)
+			err = UnmarshalContext(ctx, encoding, data, v)
 			if err != nil {
-				return err
+				return newChainError(ChainUnmarshal, index, name, err)
 			}
 		}
 	}
 	return nil
 }
+
+func (c ChainEncoding) newByteBudget() *byteBudget {
+	if c.budget <= 0 {
+		return nil
+	}
+	return &byteBudget{limit: c.budget}
+}
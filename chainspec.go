@@ -0,0 +1,252 @@
+package encodingx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aura-studio/magic"
+)
+
+var (
+	ErrChainSpecSyntax = errors.New("encoding chain spec has invalid syntax")
+)
+
+// ChainSpecError reports a chain spec that cannot be parsed. Pos is the byte
+// offset of the offending token in Spec, Err is ErrChainSpecSyntax,
+// ErrEncodingInvalidName or ErrEncodingMissingEncoding.
+type ChainSpecError struct {
+	Spec  string
+	Pos   int
+	Token string
+	Err   error
+}
+
+func (e *ChainSpecError) Error() string {
+	if e.Token == "" {
+		return fmt.Sprintf("%v at position %d in %q", e.Err, e.Pos, e.Spec)
+	}
+	return fmt.Sprintf("%v: %q at position %d in %q", e.Err, e.Token, e.Pos, e.Spec)
+}
+
+func (e *ChainSpecError) Unwrap() error {
+	return e.Err
+}
+
+// ParseChain parses spec into a chain resolving its stages in the default
+// registry. Two forms are accepted:
+//
+//	[JSON:Base64] -> [Base64:JSON]   the format produced by ChainEncoding.String
+//	JSON|Zlib|Base64                 a pipeline, decoded in reverse order
+//
+// Every name must be registered at parse time.
+func ParseChain(spec string) (*ChainEncoding, error) {
+	return defaultRegistry.ParseChain(spec)
+}
+
+// MustParseChain is like ParseChain but panics if spec cannot be parsed.
+func MustParseChain(spec string) *ChainEncoding {
+	c, err := ParseChain(spec)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// ParseChain parses spec into a chain resolving its stages in r.
+func (r *Registry) ParseChain(spec string) (*ChainEncoding, error) {
+	p := &chainSpecParser{
+		spec:     spec,
+		registry: r,
+	}
+	encoder, decoder, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return r.NewChainEncoding(encoder, decoder), nil
+}
+
+// MarshalText renders the chain in pipeline form when the decoder mirrors
+// the encoder, and in the String form otherwise.
+func (c ChainEncoding) MarshalText() ([]byte, error) {
+	if len(c.encoder) > 0 && isMirrored(c.encoder, c.decoder) {
+		return []byte(strings.Join(c.encoder, magic.SeparatorVerticalBar)), nil
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText parses a chain spec in either form accepted by ParseChain,
+// resolving names in the registry c is already bound to.
+func (c *ChainEncoding) UnmarshalText(text []byte) error {
+	parsed, err := c.Registry().ParseChain(string(text))
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+func isMirrored(encoder, decoder []string) bool {
+	if len(encoder) != len(decoder) {
+		return false
+	}
+	for index, name := range encoder {
+		if decoder[len(decoder)-1-index] != name {
+			return false
+		}
+	}
+	return true
+}
+
+type chainSpecParser struct {
+	spec     string
+	pos      int
+	registry *Registry
+}
+
+func (p *chainSpecParser) parse() (encoder, decoder []string, err error) {
+	p.skipSpace()
+	if p.pos == len(p.spec) {
+		return nil, nil, p.fail(ErrChainSpecSyntax, "")
+	}
+	if p.spec[p.pos] == magic.SeparatorBracketLeft[0] {
+		encoder, err = p.parseList()
+		if err != nil {
+			return nil, nil, err
+		}
+		p.skipSpace()
+		if !p.consume(magic.SeparatorMinus + magic.SeparatorGreater) {
+			return nil, nil, p.fail(ErrChainSpecSyntax, p.rest())
+		}
+		p.skipSpace()
+		decoder, err = p.parseList()
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		encoder, err = p.parsePipeline()
+		if err != nil {
+			return nil, nil, err
+		}
+		decoder = make([]string, len(encoder))
+		for index, name := range encoder {
+			decoder[len(encoder)-1-index] = name
+		}
+	}
+	p.skipSpace()
+	if p.pos != len(p.spec) {
+		return nil, nil, p.fail(ErrChainSpecSyntax, p.rest())
+	}
+	return encoder, decoder, nil
+}
+
+// parseList parses "[A:B:C]", an empty "[]" is allowed.
+func (p *chainSpecParser) parseList() ([]string, error) {
+	if !p.consume(magic.SeparatorBracketLeft) {
+		return nil, p.fail(ErrChainSpecSyntax, p.rest())
+	}
+	names := make([]string, 0)
+	p.skipSpace()
+	if p.consume(magic.SeparatorBracketRight) {
+		return names, nil
+	}
+	for {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		p.skipSpace()
+		if p.consume(magic.SeparatorBracketRight) {
+			return names, nil
+		}
+		if !p.consume(magic.SeparatorColon) {
+			return nil, p.fail(ErrChainSpecSyntax, p.rest())
+		}
+		p.skipSpace()
+	}
+}
+
+// parsePipeline parses "A|B|C".
+func (p *chainSpecParser) parsePipeline() ([]string, error) {
+	names := make([]string, 0)
+	for {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		p.skipSpace()
+		if !p.consume(magic.SeparatorVerticalBar) {
+			return names, nil
+		}
+		p.skipSpace()
+	}
+}
+
+func (p *chainSpecParser) parseName() (string, error) {
+	start := p.pos
+	for p.pos < len(p.spec) && !isChainSpecDelimiter(p.spec[p.pos]) {
+		p.pos++
+	}
+	name := p.spec[start:p.pos]
+	p.pos = start
+	if name == "" {
+		return "", p.fail(ErrChainSpecSyntax, p.rest())
+	}
+	if !ValidEncodingName(name) {
+		return "", p.fail(ErrEncodingInvalidName, name)
+	}
+	if _, err := p.registry.Lookup(name); err != nil {
+		return "", p.fail(err, name)
+	}
+	p.pos += len(name)
+	return name, nil
+}
+
+func (p *chainSpecParser) consume(token string) bool {
+	if strings.HasPrefix(p.spec[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *chainSpecParser) skipSpace() {
+	for p.pos < len(p.spec) && isChainSpecSpace(p.spec[p.pos]) {
+		p.pos++
+	}
+}
+
+// rest returns the remaining input up to the next delimiter, for error reports.
+func (p *chainSpecParser) rest() string {
+	end := p.pos
+	for end < len(p.spec) && !isChainSpecSpace(p.spec[end]) {
+		end++
+	}
+	if end == p.pos && end < len(p.spec) {
+		end++
+	}
+	return p.spec[p.pos:end]
+}
+
+func (p *chainSpecParser) fail(err error, token string) error {
+	return &ChainSpecError{
+		Spec:  p.spec,
+		Pos:   p.pos,
+		Token: token,
+		Err:   err,
+	}
+}
+
+func isChainSpecSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isChainSpecDelimiter(c byte) bool {
+	switch c {
+	case ':', '|', '[', ']':
+		return true
+	}
+	return isChainSpecSpace(c)
+}
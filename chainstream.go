@@ -0,0 +1,304 @@
+package encodingx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+)
+
+// NewEncoder returns an Encoder that pipes values through every encoder
+// stage into w. Stages whose encoding is a StreamEncoding transform the data
+// as it flows; any other stage buffers its input and marshals it on Close.
+// Close must be called to flush the pipeline.
+func (c ChainEncoding) NewEncoder(w io.Writer) Encoder {
+	return c.NewEncoderContext(context.Background(), w)
+}
+
+// NewEncoderContext is like NewEncoder, but every write between stages
+// fails with ctx.Err() once ctx is done.
+func (c ChainEncoding) NewEncoderContext(ctx context.Context, w io.Writer) Encoder {
+	registry := c.Registry()
+	next := newContextWriter(ctx, w)
+	closers := make([]io.Closer, len(c.encoder))
+	for index := len(c.encoder) - 1; index > 0; index-- {
+		name := c.encoder[index]
+		encoding, err := registry.Lookup(name)
+		if err != nil {
+			return chainErrorEncoder{newChainError(ChainMarshal, index, name, err)}
+		}
+		if encoding.Style() == EncodingStyleStruct {
+			return chainErrorEncoder{newChainError(ChainMarshal, index, name, ErrWrongEncodingStyle)}
+		}
+		stage := &chainStageWriter{ctx: ctx, w: newStageWriter(encoding, next), index: index, name: name}
+		closers[index] = stage
+		next = stage
+	}
+	encoder := &chainEncoder{
+		ctx:     ctx,
+		w:       next,
+		closers: closers,
+	}
+	if len(c.encoder) > 0 {
+		name := c.encoder[0]
+		encoding, err := registry.Lookup(name)
+		if err != nil {
+			return chainErrorEncoder{newChainError(ChainMarshal, 0, name, err)}
+		}
+		encoder.name = name
+		if stream, ok := encoding.(StreamEncoding); ok {
+			encoder.first = stream.NewEncoder(next)
+			closers[0] = encoder.first
+		} else {
+			encoder.marshal = encoding
+		}
+	}
+	return encoder
+}
+
+// NewDecoder returns a Decoder that pulls data from r through every decoder
+// stage. Stages whose encoding is a StreamEncoding transform the data as it
+// flows; any other stage reads its whole input before unmarshaling it.
+func (c ChainEncoding) NewDecoder(r io.Reader) Decoder {
+	return c.NewDecoderContext(context.Background(), r)
+}
+
+// NewDecoderContext is like NewDecoder, but every read between stages
+// fails with ctx.Err() once ctx is done.
+func (c ChainEncoding) NewDecoderContext(ctx context.Context, r io.Reader) Decoder {
+	registry := c.Registry()
+	budget := c.newByteBudget()
+	prev := newContextReader(ctx, r)
+	for index, name := range c.decoder {
+		encoding, err := registry.Lookup(name)
+		if err != nil {
+			return chainErrorDecoder{newChainError(ChainUnmarshal, index, name, err)}
+		}
+		if index == len(c.decoder)-1 {
+			decoder := &chainDecoder{ctx: ctx, index: index, name: name}
+			if stream, ok := encoding.(StreamEncoding); ok {
+				decoder.last = stream.NewDecoder(prev)
+			} else {
+				decoder.r = prev
+				decoder.encoding = encoding
+			}
+			return decoder
+		}
+		if encoding.Style() == EncodingStyleStruct {
+			return chainErrorDecoder{newChainError(ChainUnmarshal, index, name, ErrWrongEncodingStyle)}
+		}
+		stage := newStageReader(encoding, prev)
+		if budget != nil {
+			stage = newLimitReader(stage, decompressLimits{}, nil, budget)
+		}
+		prev = &chainStageReader{ctx: ctx, r: stage, index: index, name: name}
+	}
+	return &chainDecoder{ctx: ctx}
+}
+
+type chainEncoder struct {
+	ctx     context.Context
+	w       io.Writer
+	name    string
+	first   Encoder
+	marshal Encoding
+	closers []io.Closer
+}
+
+func (e *chainEncoder) Encode(v interface{}) error {
+	if err := e.ctx.Err(); err != nil {
+		return newChainError(ChainMarshal, 0, e.name, err)
+	}
+	switch {
+	case e.first != nil:
+		return tagChainError(ChainMarshal, 0, e.name, e.first.Encode(v))
+	case e.marshal != nil:
+		data, err := MarshalContext(e.ctx, e.marshal, v)
+		if err != nil {
+			return newChainError(ChainMarshal, 0, e.name, err)
+		}
+		_, err = e.w.Write(data)
+		return tagChainError(ChainMarshal, 0, e.name, err)
+	default:
+		return nil
+	}
+}
+
+// Close flushes the stages front to back, so every stage has received all
+// of its input before it is closed itself.
+func (e *chainEncoder) Close() error {
+	for _, closer := range e.closers {
+		if closer == nil {
+			continue
+		}
+		if err := closer.Close(); err != nil {
+			return tagChainError(ChainMarshal, 0, e.name, err)
+		}
+	}
+	return nil
+}
+
+type chainDecoder struct {
+	ctx      context.Context
+	index    int
+	name     string
+	last     Decoder
+	r        io.Reader
+	encoding Encoding
+}
+
+func (d *chainDecoder) Decode(v interface{}) error {
+	if err := d.ctx.Err(); err != nil {
+		return newChainError(ChainUnmarshal, d.index, d.name, err)
+	}
+	switch {
+	case d.last != nil:
+		err := d.last.Decode(v)
+		return tagChainError(ChainUnmarshal, d.index, d.name, contextError(d.ctx, err))
+	case d.encoding != nil:
+		data, err := io.ReadAll(d.r)
+		if err != nil {
+			return tagChainError(ChainUnmarshal, d.index, d.name, err)
+		}
+		return tagChainError(ChainUnmarshal, d.index, d.name, UnmarshalContext(d.ctx, d.encoding, data, v))
+	default:
+		return nil
+	}
+}
+
+type chainErrorEncoder struct {
+	err error
+}
+
+func (e chainErrorEncoder) Encode(interface{}) error {
+	return e.err
+}
+
+func (e chainErrorEncoder) Close() error {
+	return e.err
+}
+
+type chainErrorDecoder struct {
+	err error
+}
+
+func (d chainErrorDecoder) Decode(interface{}) error {
+	return d.err
+}
+
+// newStageWriter returns the writer a bytes style stage receives its input
+// through.
+func newStageWriter(encoding Encoding, next io.Writer) io.WriteCloser {
+	if stream, ok := encoding.(StreamEncoding); ok {
+		if w, ok := stream.NewEncoder(next).(io.WriteCloser); ok {
+			return w
+		}
+	}
+	return &bufferedStageWriter{encoding: encoding, next: next}
+}
+
+// newStageReader returns the reader a bytes style stage yields its output
+// through.
+func newStageReader(encoding Encoding, prev io.Reader) io.Reader {
+	if stream, ok := encoding.(StreamEncoding); ok {
+		if r, ok := stream.NewDecoder(prev).(io.Reader); ok {
+			return r
+		}
+	}
+	return &bufferedStageReader{encoding: encoding, prev: prev}
+}
+
+// bufferedStageWriter is the fallback for stages that cannot stream.
+type bufferedStageWriter struct {
+	buf      bytes.Buffer
+	encoding Encoding
+	next     io.Writer
+}
+
+func (w *bufferedStageWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *bufferedStageWriter) Close() error {
+	data, err := w.encoding.Marshal(w.buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.next.Write(data)
+	return err
+}
+
+// bufferedStageReader is the fallback for stages that cannot stream.
+type bufferedStageReader struct {
+	encoding Encoding
+	prev     io.Reader
+	r        *bytes.Reader
+}
+
+func (r *bufferedStageReader) Read(p []byte) (int, error) {
+	if r.r == nil {
+		data, err := io.ReadAll(r.prev)
+		if err != nil {
+			return 0, err
+		}
+		decoded := MakeBytes(nil)
+		if err := r.encoding.Unmarshal(data, &decoded); err != nil {
+			return 0, err
+		}
+		r.r = bytes.NewReader(decoded.Data)
+	}
+	return r.r.Read(p)
+}
+
+// chainStageWriter attributes errors of a stage to it and stops the
+// pipeline once ctx is done.
+type chainStageWriter struct {
+	ctx   context.Context
+	w     io.WriteCloser
+	index int
+	name  string
+}
+
+func (w *chainStageWriter) Write(p []byte) (int, error) {
+	if err := w.ctx.Err(); err != nil {
+		return 0, newChainError(ChainMarshal, w.index, w.name, err)
+	}
+	n, err := w.w.Write(p)
+	return n, tagChainError(ChainMarshal, w.index, w.name, err)
+}
+
+func (w *chainStageWriter) Close() error {
+	return tagChainError(ChainMarshal, w.index, w.name, w.w.Close())
+}
+
+// chainStageReader attributes errors of a stage to it and stops the
+// pipeline once ctx is done.
+type chainStageReader struct {
+	ctx   context.Context
+	r     io.Reader
+	index int
+	name  string
+}
+
+func (r *chainStageReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, newChainError(ChainUnmarshal, r.index, r.name, err)
+	}
+	n, err := r.r.Read(p)
+	if err == io.EOF {
+		return n, err
+	}
+	return n, tagChainError(ChainUnmarshal, r.index, r.name, err)
+}
+
+// tagChainError wraps err in a ChainError unless a later stage already did.
+func tagChainError(direction ChainDirection, index int, name string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var chainErr *ChainError
+	if errors.As(err, &chainErr) {
+		return err
+	}
+	return newChainError(direction, index, name, err)
+}
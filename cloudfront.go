@@ -35,26 +35,31 @@ func (CloudFrontURLSafe) Style() EncodingStyleType {
 	return EncodingStyleBytes
 }
 
-func (CloudFrontURLSafe) Marshal(v interface{}) ([]byte, error) {
-	var data []byte
-	switch v := v.(type) {
-	case []byte:
-		data = v
-	case Bytes:
-		data = v.Data
-	case *Bytes:
-		data = v.Data
-	default:
+func (c CloudFrontURLSafe) Marshal(v interface{}) ([]byte, error) {
+	return c.AppendMarshal(nil, v)
+}
+
+func (CloudFrontURLSafe) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
 		return nil, ErrCloudFrontURLSafeWrongValueType
 	}
 
 	// Standard base64 encode
-	s := base64.StdEncoding.EncodeToString(data)
+	start := len(dst)
+	dst = base64.StdEncoding.AppendEncode(dst, data)
 	// CloudFront URL-safe replacements
-	s = strings.ReplaceAll(s, "+", "-")
-	s = strings.ReplaceAll(s, "=", "_")
-	s = strings.ReplaceAll(s, "/", "~")
-	return []byte(s), nil
+	for i := start; i < len(dst); i++ {
+		switch dst[i] {
+		case '+':
+			dst[i] = '-'
+		case '=':
+			dst[i] = '_'
+		case '/':
+			dst[i] = '~'
+		}
+	}
+	return dst, nil
 }
 
 func (CloudFrontURLSafe) Unmarshal(data []byte, v interface{}) error {
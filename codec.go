@@ -0,0 +1,100 @@
+package encodingx
+
+import (
+	"reflect"
+)
+
+// Codec binds an Encoding to a payload type, so values are checked at
+// compile time instead of being reported as wrong type values at runtime.
+type Codec[T any] struct {
+	encoding Encoding
+}
+
+// NewCodec creates a Codec for T on top of e.
+func NewCodec[T any](e Encoding) Codec[T] {
+	return Codec[T]{
+		encoding: e,
+	}
+}
+
+// LookupCodec creates a Codec for T on top of the encoding registered under
+// name in the default registry.
+func LookupCodec[T any](name string) (Codec[T], error) {
+	e, err := Lookup(name)
+	if err != nil {
+		return Codec[T]{}, err
+	}
+	return NewCodec[T](e), nil
+}
+
+// Encoding returns the underlying encoding.
+func (c Codec[T]) Encoding() Encoding {
+	return c.encoding
+}
+
+func (c Codec[T]) Marshal(v T) ([]byte, error) {
+	return c.encoding.Marshal(v)
+}
+
+// Unmarshal decodes data into a new T. When T is a pointer type the value
+// it points to is allocated, so pointer-only targets such as protobuf
+// messages work too.
+func (c Codec[T]) Unmarshal(data []byte) (T, error) {
+	v, target := newCodecTarget[T]()
+	if err := c.encoding.Unmarshal(data, target); err != nil {
+		var zero T
+		return zero, err
+	}
+	return *v, nil
+}
+
+func (c Codec[T]) MustMarshal(v T) []byte {
+	return Encode(c.encoding, v)
+}
+
+func (c Codec[T]) MustUnmarshal(data []byte) T {
+	v, err := c.Unmarshal(data)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MarshalSlice encodes vs as one value, for encodings holding sequences
+// such as JSON arrays or CSV rows.
+func (c Codec[T]) MarshalSlice(vs []T) ([]byte, error) {
+	return c.encoding.Marshal(vs)
+}
+
+func (c Codec[T]) UnmarshalSlice(data []byte) ([]T, error) {
+	var vs []T
+	if err := c.encoding.Unmarshal(data, &vs); err != nil {
+		return nil, err
+	}
+	return vs, nil
+}
+
+// MarshalMap encodes m as one value with c's encoding.
+func MarshalMap[K comparable, V any](c Codec[V], m map[K]V) ([]byte, error) {
+	return c.encoding.Marshal(m)
+}
+
+func UnmarshalMap[K comparable, V any](c Codec[V], data []byte) (map[K]V, error) {
+	var m map[K]V
+	if err := c.encoding.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// newCodecTarget returns a new T and what Unmarshal should decode into.
+func newCodecTarget[T any]() (*T, interface{}) {
+	v := new(T)
+	t := reflect.TypeOf(v).Elem()
+	if t.Kind() == reflect.Ptr {
+		elem := reflect.New(t.Elem())
+		reflect.ValueOf(v).Elem().Set(elem)
+		return v, elem.Interface()
+	}
+	return v, v
+}
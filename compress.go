@@ -0,0 +1,442 @@
+package encodingx
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrGzipWrongValueType    = errors.New("encoding gzip converts on wrong type value")
+	ErrDeflateWrongValueType = errors.New("encoding deflate converts on wrong type value")
+	ErrZlibWrongValueType    = errors.New("encoding zlib converts on wrong type value")
+)
+
+func init() {
+	register(NewGzip())
+	register(NewDeflate())
+	register(NewZlib())
+}
+
+// ============================================================================
+// Gzip - RFC 1952 gzip stream
+// Unmarshal accepts concatenated gzip members, as gunzip does.
+// ============================================================================
+
+type Gzip struct {
+	options
+}
+
+// NewGzip creates a Gzip encoding, honoring WithName, WithLevel and the
+// decompression limits.
+func NewGzip(opts ...CompressOption) *Gzip {
+	return &Gzip{
+		options: newOptions(opts, CompressOption.applyCompress),
+	}
+}
+
+func (g Gzip) String() string {
+	return g.nameOr(reflectx.TypeName(g))
+}
+
+func (Gzip) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (g Gzip) Marshal(v interface{}) ([]byte, error) {
+	return g.AppendMarshal(nil, v)
+}
+
+func (g Gzip) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	return g.appendMarshal(context.Background(), dst, v)
+}
+
+// MarshalContext is like Marshal but stops compressing once ctx is done.
+func (g Gzip) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	return g.appendMarshal(ctx, nil, v)
+}
+
+func (g Gzip) appendMarshal(ctx context.Context, dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrGzipWrongValueType
+	}
+	return gzipFormat.appendCompress(ctx, dst, data, g.levelOr(gzip.DefaultCompression), nil)
+}
+
+func (g Gzip) Unmarshal(data []byte, v interface{}) error {
+	return g.UnmarshalContext(context.Background(), data, v)
+}
+
+// UnmarshalContext is like Unmarshal but stops inflating once ctx is done.
+func (g Gzip) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decompressed, err := gzipFormat.decompress(ctx, data, nil, g.decompressLimits())
+		if err != nil {
+			return err
+		}
+		v.Data = decompressed
+		return nil
+	default:
+		return ErrGzipWrongValueType
+	}
+}
+
+func (g Gzip) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(gzipFormat.newStreamWriter(w, g.levelOr(gzip.DefaultCompression), nil), ErrGzipWrongValueType)
+}
+
+func (g Gzip) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(gzipFormat.newStreamReader(r, nil, g.decompressLimits()), ErrGzipWrongValueType)
+}
+
+func (g Gzip) Reverse() Encoding {
+	return g
+}
+
+// ============================================================================
+// Deflate - RFC 1951 raw deflate stream, without header or checksum
+// ============================================================================
+
+type Deflate struct {
+	options
+}
+
+// NewDeflate creates a Deflate encoding, honoring WithName, WithLevel,
+// WithDictionary and the decompression limits.
+func NewDeflate(opts ...DictionaryCompressOption) *Deflate {
+	return &Deflate{
+		options: newOptions(opts, DictionaryCompressOption.applyDictionaryCompress),
+	}
+}
+
+func (d Deflate) String() string {
+	return d.nameOr(reflectx.TypeName(d))
+}
+
+func (Deflate) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (d Deflate) Marshal(v interface{}) ([]byte, error) {
+	return d.AppendMarshal(nil, v)
+}
+
+func (d Deflate) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	return d.appendMarshal(context.Background(), dst, v)
+}
+
+// MarshalContext is like Marshal but stops compressing once ctx is done.
+func (d Deflate) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	return d.appendMarshal(ctx, nil, v)
+}
+
+func (d Deflate) appendMarshal(ctx context.Context, dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrDeflateWrongValueType
+	}
+	return deflateFormat.appendCompress(ctx, dst, data, d.levelOr(flate.DefaultCompression), d.dictionary())
+}
+
+func (d Deflate) Unmarshal(data []byte, v interface{}) error {
+	return d.UnmarshalContext(context.Background(), data, v)
+}
+
+// UnmarshalContext is like Unmarshal but stops inflating once ctx is done.
+func (d Deflate) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decompressed, err := deflateFormat.decompress(ctx, data, d.dictionary(), d.decompressLimits())
+		if err != nil {
+			return err
+		}
+		v.Data = decompressed
+		return nil
+	default:
+		return ErrDeflateWrongValueType
+	}
+}
+
+func (d Deflate) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(deflateFormat.newStreamWriter(w, d.levelOr(flate.DefaultCompression), d.dictionary()), ErrDeflateWrongValueType)
+}
+
+func (d Deflate) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(deflateFormat.newStreamReader(r, d.dictionary(), d.decompressLimits()), ErrDeflateWrongValueType)
+}
+
+func (d Deflate) Reverse() Encoding {
+	return d
+}
+
+// ============================================================================
+// Zlib - RFC 1950 zlib stream, deflate with a header and an Adler-32 checksum
+// ============================================================================
+
+type Zlib struct {
+	options
+}
+
+// NewZlib creates a Zlib encoding, honoring WithName, WithLevel,
+// WithDictionary and the decompression limits.
+func NewZlib(opts ...DictionaryCompressOption) *Zlib {
+	return &Zlib{
+		options: newOptions(opts, DictionaryCompressOption.applyDictionaryCompress),
+	}
+}
+
+func (z Zlib) String() string {
+	return z.nameOr(reflectx.TypeName(z))
+}
+
+func (Zlib) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (z Zlib) Marshal(v interface{}) ([]byte, error) {
+	return z.AppendMarshal(nil, v)
+}
+
+func (z Zlib) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	return z.appendMarshal(context.Background(), dst, v)
+}
+
+// MarshalContext is like Marshal but stops compressing once ctx is done.
+func (z Zlib) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	return z.appendMarshal(ctx, nil, v)
+}
+
+func (z Zlib) appendMarshal(ctx context.Context, dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrZlibWrongValueType
+	}
+	return zlibFormat.appendCompress(ctx, dst, data, z.levelOr(zlib.DefaultCompression), z.dictionary())
+}
+
+func (z Zlib) Unmarshal(data []byte, v interface{}) error {
+	return z.UnmarshalContext(context.Background(), data, v)
+}
+
+// UnmarshalContext is like Unmarshal but stops inflating once ctx is done.
+func (z Zlib) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decompressed, err := zlibFormat.decompress(ctx, data, z.dictionary(), z.decompressLimits())
+		if err != nil {
+			return err
+		}
+		v.Data = decompressed
+		return nil
+	default:
+		return ErrZlibWrongValueType
+	}
+}
+
+func (z Zlib) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(zlibFormat.newStreamWriter(w, z.levelOr(zlib.DefaultCompression), z.dictionary()), ErrZlibWrongValueType)
+}
+
+func (z Zlib) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(zlibFormat.newStreamReader(r, z.dictionary(), z.decompressLimits()), ErrZlibWrongValueType)
+}
+
+func (z Zlib) Reverse() Encoding {
+	return z
+}
+
+// ============================================================================
+// Helper functions
+// ============================================================================
+
+// compressWriter is the writer side of a compressed format, which can be
+// reused for another stream after Close.
+type compressWriter interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// compressFormat describes one compressed format to the helpers shared by
+// the compressing encodings. Each format reads level in its own range and
+// ignores a dictionary it has no use for.
+type compressFormat struct {
+	newWriter func(w io.Writer, level int, dict []byte) (compressWriter, error)
+	newReader func(r io.Reader, dict []byte) (io.ReadCloser, error)
+
+	// pools holds a pool per compression level from -2 to 9 for writers
+	// without dictionary, a writer keeps its level and dictionary across
+	// Reset.
+	pools [flate.BestCompression - flate.HuffmanOnly + 1]sync.Pool
+}
+
+var (
+	gzipFormat = &compressFormat{
+		newWriter: func(w io.Writer, level int, _ []byte) (compressWriter, error) {
+			gw, err := gzip.NewWriterLevel(w, level)
+			if err != nil {
+				return nil, err
+			}
+			return gw, nil
+		},
+		newReader: func(r io.Reader, _ []byte) (io.ReadCloser, error) {
+			gr, err := gzip.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			return gr, nil
+		},
+	}
+	deflateFormat = &compressFormat{
+		newWriter: func(w io.Writer, level int, dict []byte) (compressWriter, error) {
+			fw, err := flate.NewWriterDict(w, level, dict)
+			if err != nil {
+				return nil, err
+			}
+			return fw, nil
+		},
+		newReader: func(r io.Reader, dict []byte) (io.ReadCloser, error) {
+			return flate.NewReaderDict(r, dict), nil
+		},
+	}
+	zlibFormat = &compressFormat{
+		newWriter: func(w io.Writer, level int, dict []byte) (compressWriter, error) {
+			zw, err := zlib.NewWriterLevelDict(w, level, dict)
+			if err != nil {
+				return nil, err
+			}
+			return zw, nil
+		},
+		newReader: func(r io.Reader, dict []byte) (io.ReadCloser, error) {
+			return zlib.NewReaderDict(r, dict)
+		},
+	}
+
+	compressBufferPool = sync.Pool{
+		New: func() interface{} {
+			return new(bytes.Buffer)
+		},
+	}
+)
+
+// getWriter returns a writer compressing into w at level, pooled when
+// there is no dictionary.
+func (f *compressFormat) getWriter(w io.Writer, level int, dict []byte) (compressWriter, error) {
+	if !pooledLevel(level, dict) {
+		return f.newWriter(w, level, dict)
+	}
+	if cw, ok := f.pools[level-flate.HuffmanOnly].Get().(compressWriter); ok {
+		cw.Reset(w)
+		return cw, nil
+	}
+	return f.newWriter(w, level, nil)
+}
+
+func (f *compressFormat) putWriter(cw compressWriter, level int, dict []byte) {
+	if pooledLevel(level, dict) {
+		f.pools[level-flate.HuffmanOnly].Put(cw)
+	}
+}
+
+func pooledLevel(level int, dict []byte) bool {
+	return dict == nil && level >= flate.HuffmanOnly && level <= flate.BestCompression
+}
+
+// appendCompress appends data compressed at level to dst.
+func (f *compressFormat) appendCompress(ctx context.Context, dst, data []byte, level int, dict []byte) ([]byte, error) {
+	buf := compressBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer compressBufferPool.Put(buf)
+	w, err := f.getWriter(buf, level, dict)
+	if err != nil {
+		return nil, err
+	}
+	defer f.putWriter(w, level, dict)
+	if _, err := newContextWriter(ctx, w).Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return append(dst, buf.Bytes()...), nil
+}
+
+// decompress fails with ErrDecompressedTooLarge once the data breaks
+// limits or the byte budget ctx carries.
+func (f *compressFormat) decompress(ctx context.Context, data, dict []byte, limits decompressLimits) ([]byte, error) {
+	r, err := f.newReader(bytes.NewReader(data), dict)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	input := int64(len(data))
+	return io.ReadAll(newLimitReader(newContextReader(ctx, r), limits, func() int64 { return input }, byteBudgetFrom(ctx)))
+}
+
+// newStreamWriter returns a writer compressing into w. An invalid level is
+// reported by its first Write or Close.
+func (f *compressFormat) newStreamWriter(w io.Writer, level int, dict []byte) io.WriteCloser {
+	cw, err := f.newWriter(w, level, dict)
+	if err != nil {
+		return failedWriteCloser{err}
+	}
+	return cw
+}
+
+// newStreamReader returns a reader decompressing from r within limits. The
+// stream header is only read on the first Read, so that creating a decoder
+// never blocks.
+func (f *compressFormat) newStreamReader(r io.Reader, dict []byte, limits decompressLimits) io.Reader {
+	return &compressReader{format: f, input: &countingReader{r: r}, dict: dict, limits: limits}
+}
+
+type compressReader struct {
+	format *compressFormat
+	input  *countingReader
+	dict   []byte
+	limits decompressLimits
+	rc     io.ReadCloser
+	r      io.Reader
+	err    error
+}
+
+func (cr *compressReader) Read(p []byte) (int, error) {
+	if cr.err != nil {
+		return 0, cr.err
+	}
+	if cr.rc == nil {
+		cr.rc, cr.err = cr.format.newReader(cr.input, cr.dict)
+		if cr.err != nil {
+			return 0, cr.err
+		}
+		cr.r = newLimitReader(cr.rc, cr.limits, func() int64 { return cr.input.n }, nil)
+	}
+	n, err := cr.r.Read(p)
+	if err != nil {
+		// Release what the decompressor holds, the stream is done
+		cr.rc.Close()
+		cr.err = err
+	}
+	return n, err
+}
+
+// failedWriteCloser fails every call with err.
+type failedWriteCloser struct {
+	err error
+}
+
+func (w failedWriteCloser) Write([]byte) (int, error) {
+	return 0, w.err
+}
+
+func (w failedWriteCloser) Close() error {
+	return w.err
+}
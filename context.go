@@ -0,0 +1,109 @@
+package encodingx
+
+import (
+	"context"
+	"io"
+)
+
+// ContextEncoding is implemented by encodings whose work can be abandoned
+// part way when ctx is done, in which case ctx.Err() is returned.
+type ContextEncoding interface {
+	Encoding
+	MarshalContext(ctx context.Context, v interface{}) ([]byte, error)
+	UnmarshalContext(ctx context.Context, data []byte, v interface{}) error
+}
+
+// MarshalContext marshals v with e, through MarshalContext when e is a
+// ContextEncoding. Otherwise ctx is only checked before e starts.
+func MarshalContext(ctx context.Context, e Encoding, v interface{}) ([]byte, error) {
+	if ce, ok := e.(ContextEncoding); ok {
+		return ce.MarshalContext(ctx, v)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return e.Marshal(v)
+}
+
+// UnmarshalContext unmarshals data with e, through UnmarshalContext when e
+// is a ContextEncoding. Otherwise ctx is only checked before e starts.
+func UnmarshalContext(ctx context.Context, e Encoding, data []byte, v interface{}) error {
+	if ce, ok := e.(ContextEncoding); ok {
+		return ce.UnmarshalContext(ctx, data, v)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return e.Unmarshal(data, v)
+}
+
+// contextError reports ctx.Err() in place of a failure caused by it, as
+// decoders do not always keep the error of their reader intact.
+func contextError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+// contextChunk bounds how much is read or written between two checks of ctx.
+const contextChunk = 32 << 10
+
+// contextReader fails with ctx.Err() once ctx is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func newContextReader(ctx context.Context, r io.Reader) io.Reader {
+	if ctx.Done() == nil {
+		return r
+	}
+	return &contextReader{ctx: ctx, r: r}
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	if len(p) > contextChunk {
+		p = p[:contextChunk]
+	}
+	return r.r.Read(p)
+}
+
+// contextWriter fails with ctx.Err() once ctx is done.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func newContextWriter(ctx context.Context, w io.Writer) io.Writer {
+	if ctx.Done() == nil {
+		return w
+	}
+	return &contextWriter{ctx: ctx, w: w}
+}
+
+func (w *contextWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if err := w.ctx.Err(); err != nil {
+			return written, err
+		}
+		chunk := p
+		if len(chunk) > contextChunk {
+			chunk = chunk[:contextChunk]
+		}
+		n, err := w.w.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[n:]
+	}
+	return written, nil
+}
@@ -2,78 +2,202 @@ package encodingx
 
 import (
 	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
 
 	"github.com/aura-studio/reflectx"
 
 	"github.com/gocarina/gocsv"
 )
 
-type CSV struct{}
+type CSV struct {
+	options
+}
 
 func init() {
 	register(NewCSV())
 }
 
-func NewCSV() *CSV {
-	return new(CSV)
+// NewCSV creates a CSV encoding without header line, honoring WithName and
+// WithDelimiter.
+func NewCSV(opts ...CSVOption) *CSV {
+	return &CSV{
+		options: newOptions(opts, CSVOption.applyCSV),
+	}
 }
 
-func (csv CSV) String() string {
-	return reflectx.TypeName(csv)
+func (c CSV) String() string {
+	return c.nameOr(reflectx.TypeName(c))
 }
 
 func (CSV) Style() EncodingStyleType {
 	return EncodingStyleStruct
 }
 
-func (CSV) Marshal(v interface{}) ([]byte, error) {
+func (c CSV) Marshal(v interface{}) ([]byte, error) {
 	var buf = new(bytes.Buffer)
-	err := gocsv.MarshalWithoutHeaders(v, buf)
+	err := marshalCSV(v, buf, c.delimiter, false)
 	if err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
-func (CSV) Unmarshal(data []byte, v interface{}) error {
-	return gocsv.UnmarshalWithoutHeaders(bytes.NewBuffer(data), v)
+func (c CSV) Unmarshal(data []byte, v interface{}) error {
+	return unmarshalCSV(bytes.NewBuffer(data), v, c.delimiter, false)
+}
+
+// MarshalContext is like Marshal but stops writing rows once ctx is done.
+func (c CSV) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	var buf = new(bytes.Buffer)
+	err := marshalCSV(v, newContextWriter(ctx, buf), c.delimiter, false)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalContext is like Unmarshal but stops reading rows once ctx is done.
+func (c CSV) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	err := unmarshalCSV(newContextReader(ctx, bytes.NewReader(data)), v, c.delimiter, false)
+	return contextError(ctx, err)
 }
 
-func (csv CSV) Reverse() Encoding {
-	return csv
+// NewEncoder returns an Encoder appending the rows of each value to w.
+func (c CSV) NewEncoder(w io.Writer) Encoder {
+	return streamEncoder{
+		encode: func(v interface{}) error {
+			return marshalCSV(v, w, c.delimiter, false)
+		},
+	}
+}
+
+// NewDecoder returns a Decoder reading all remaining rows from r.
+func (c CSV) NewDecoder(r io.Reader) Decoder {
+	return streamDecoder{
+		decode: func(v interface{}) error {
+			return unmarshalCSV(r, v, c.delimiter, false)
+		},
+	}
+}
+
+func (c CSV) Reverse() Encoding {
+	return c
 }
 
-type CSVWithHeaders struct{}
+type CSVWithHeaders struct {
+	options
+}
 
 func init() {
 	register(NewCSVWithHeaders())
 }
 
-func NewCSVWithHeaders() *CSVWithHeaders {
-	return new(CSVWithHeaders)
+// NewCSVWithHeaders creates a CSV encoding with header line, honoring
+// WithName and WithDelimiter.
+func NewCSVWithHeaders(opts ...CSVOption) *CSVWithHeaders {
+	return &CSVWithHeaders{
+		options: newOptions(opts, CSVOption.applyCSV),
+	}
 }
 
 func (csvwh CSVWithHeaders) String() string {
-	return reflectx.TypeName(csvwh)
+	return csvwh.nameOr(reflectx.TypeName(csvwh))
 }
 
 func (CSVWithHeaders) Style() EncodingStyleType {
 	return EncodingStyleStruct
 }
 
-func (CSVWithHeaders) Marshal(v interface{}) ([]byte, error) {
+func (csvwh CSVWithHeaders) Marshal(v interface{}) ([]byte, error) {
 	var buf = new(bytes.Buffer)
-	err := gocsv.Marshal(v, buf)
+	err := marshalCSV(v, buf, csvwh.delimiter, true)
 	if err != nil {
 		return nil, err
 	}
 	return buf.Bytes(), nil
 }
 
-func (CSVWithHeaders) Unmarshal(data []byte, v interface{}) error {
-	return gocsv.Unmarshal(bytes.NewBuffer(data), v)
+func (csvwh CSVWithHeaders) Unmarshal(data []byte, v interface{}) error {
+	return unmarshalCSV(bytes.NewBuffer(data), v, csvwh.delimiter, true)
+}
+
+// MarshalContext is like Marshal but stops writing rows once ctx is done.
+func (csvwh CSVWithHeaders) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	var buf = new(bytes.Buffer)
+	err := marshalCSV(v, newContextWriter(ctx, buf), csvwh.delimiter, true)
+	if err != nil {
+		return nil, contextError(ctx, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalContext is like Unmarshal but stops reading rows once ctx is done.
+func (csvwh CSVWithHeaders) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	err := unmarshalCSV(newContextReader(ctx, bytes.NewReader(data)), v, csvwh.delimiter, true)
+	return contextError(ctx, err)
+}
+
+// NewEncoder returns an Encoder writing the header line with the first
+// value and only rows for the following ones.
+func (csvwh CSVWithHeaders) NewEncoder(w io.Writer) Encoder {
+	headerWritten := false
+	return streamEncoder{
+		encode: func(v interface{}) error {
+			if headerWritten {
+				return marshalCSV(v, w, csvwh.delimiter, false)
+			}
+			headerWritten = true
+			return marshalCSV(v, w, csvwh.delimiter, true)
+		},
+	}
+}
+
+// NewDecoder returns a Decoder reading the header line and all remaining rows from r.
+func (csvwh CSVWithHeaders) NewDecoder(r io.Reader) Decoder {
+	return streamDecoder{
+		decode: func(v interface{}) error {
+			return unmarshalCSV(r, v, csvwh.delimiter, true)
+		},
+	}
 }
 
 func (csvwh CSVWithHeaders) Reverse() Encoding {
 	return csvwh
 }
+
+// marshalCSV writes the rows of v to w, preceded by the header line when
+// headers is set. Without a delimiter the writer and reader set through
+// gocsv.SetCSVWriter and gocsv.SetCSVReader are used.
+func marshalCSV(v interface{}, w io.Writer, delimiter rune, headers bool) error {
+	if delimiter == 0 {
+		if headers {
+			return gocsv.Marshal(v, w)
+		}
+		return gocsv.MarshalWithoutHeaders(v, w)
+	}
+	writer := gocsv.DefaultCSVWriter(w)
+	writer.Comma = delimiter
+	if headers {
+		return gocsv.MarshalCSV(v, writer)
+	}
+	return gocsv.MarshalCSVWithoutHeaders(v, writer)
+}
+
+// unmarshalCSV reads the rows of r into v, the first one being the header
+// line when headers is set.
+func unmarshalCSV(r io.Reader, v interface{}, delimiter rune, headers bool) error {
+	if delimiter == 0 {
+		if headers {
+			return gocsv.Unmarshal(r, v)
+		}
+		return gocsv.UnmarshalWithoutHeaders(r, v)
+	}
+	reader := csv.NewReader(r)
+	reader.Comma = delimiter
+	if headers {
+		return gocsv.UnmarshalCSV(reader, v)
+	}
+	return gocsv.UnmarshalCSVWithoutHeaders(reader, v)
+}
@@ -0,0 +1,16 @@
+// Package encodingx provides named encodings, such as JSON, Base64 or
+// HexZlib, that convert between values and bytes, and chains of them such
+// as "JSON|Gzip|Base64".
+//
+// Decompressing encodings yield at most DefaultMaxDecompressedSize bytes
+// unless configured otherwise with WithMaxDecompressedSize, and fail with
+// ErrDecompressedTooLarge beyond that. This bound also applies to HexZlib,
+// which decompressed without limit before; data larger than 64 MiB needs
+// NewHexZlib(WithMaxDecompressedSize(n)), or a size of 0 for no bound.
+//
+// Encodings implementing ContextEncoding stop once their context is done.
+// The stream compressors, such as Gzip or Zstd, CSV and YAML check it as
+// they go, and chains between stages. TOML is the exception: its decoder runs in one
+// uninterruptible call, so the context is only checked before and after
+// it and a cancelled TOML decode still costs the whole parse.
+package encodingx
@@ -3,8 +3,6 @@ package encodingx
 import (
 	"errors"
 	"fmt"
-
-	"github.com/aura-studio/reflectx"
 )
 
 var (
@@ -86,35 +84,6 @@ func (b Bytes) Copy(in Bytes) {
 	copy(b.Data, in.Data)
 }
 
-type EncodingSet map[string]Encoding
-
-func newEncodingSet() EncodingSet {
-	return make(map[string]Encoding)
-}
-
-var encodingSet = newEncodingSet()
-
-func register(encodings ...Encoding) {
-	encodingSet.register(encodings...)
-}
-
-func (es EncodingSet) register(encodings ...Encoding) {
-	for _, encoding := range encodings {
-		es[reflectx.TypeName(encoding)] = encoding
-	}
-}
-
-func localEncoding(name string) (Encoding, error) {
-	return encodingSet.locateEncoding(name)
-}
-
-func (es EncodingSet) locateEncoding(name string) (Encoding, error) {
-	if e, ok := es[name]; ok {
-		return e, nil
-	}
-	return nil, ErrEncodingMissingEncoding
-}
-
 func Marshal(e Encoding, v interface{}) ([]byte, error) {
 	return e.Marshal(v)
 }
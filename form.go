@@ -0,0 +1,425 @@
+package encodingx
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrFormURLEncodedWrongValueType = errors.New("encoding form converts on wrong type value")
+)
+
+var (
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ============================================================================
+// FormURLEncoded - application/x-www-form-urlencoded for structs and maps
+// Fields are named by their `form:"name,omitempty"` tag, or `form:"-"` to
+// skip them. Nested structs and maps use bracketed keys such as
+// user[name]=x, slices of scalars repeat their key and slices of structs
+// are indexed as items[0][name]=x, with indexes from 0 and without gaps.
+// Values implementing
+// encoding.TextMarshaler and encoding.TextUnmarshaler are used as scalars.
+// ============================================================================
+
+type FormURLEncoded struct{}
+
+func init() {
+	register(NewFormURLEncoded())
+}
+
+func NewFormURLEncoded() *FormURLEncoded {
+	return new(FormURLEncoded)
+}
+
+func (f FormURLEncoded) String() string {
+	return reflectx.TypeName(f)
+}
+
+func (FormURLEncoded) Style() EncodingStyleType {
+	return EncodingStyleStruct
+}
+
+func (FormURLEncoded) Marshal(v interface{}) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct && rv.Kind() != reflect.Map {
+		return nil, ErrFormURLEncodedWrongValueType
+	}
+	values := url.Values{}
+	if err := encodeForm(values, "", rv); err != nil {
+		return nil, err
+	}
+	return []byte(values.Encode()), nil
+}
+
+func (FormURLEncoded) Unmarshal(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrFormURLEncodedWrongValueType
+	}
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct && rv.Kind() != reflect.Map {
+		return ErrFormURLEncodedWrongValueType
+	}
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+	return decodeForm(values, "", rv)
+}
+
+func (f FormURLEncoded) Reverse() Encoding {
+	return f
+}
+
+type formField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+// formFields lists the fields of struct type t, with embedded structs
+// without tag flattened into it.
+func formFields(t reflect.Type) []formField {
+	var fields []formField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for _, inner := range formFields(field.Type) {
+				inner.index = append([]int{i}, inner.index...)
+				fields = append(fields, inner)
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, formField{
+			name:      name,
+			index:     []int{i},
+			omitEmpty: opts == "omitempty",
+		})
+	}
+	return fields
+}
+
+func formKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "[" + name + "]"
+}
+
+// isFormScalar reports whether values of t are written as a single value.
+func isFormScalar(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Interface:
+		return false
+	case reflect.Slice:
+		return t.Elem().Kind() == reflect.Uint8
+	case reflect.Array:
+		return false
+	default:
+		return true
+	}
+}
+
+func encodeForm(values url.Values, key string, rv reflect.Value) error {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Type().Implements(textMarshalerType) {
+		text, err := rv.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return fmt.Errorf("encoding form key %q: %w", key, err)
+		}
+		values.Add(key, string(text))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		for _, field := range formFields(rv.Type()) {
+			fv := rv.FieldByIndex(field.index)
+			if field.omitEmpty && fv.IsZero() {
+				continue
+			}
+			if err := encodeForm(values, formKey(key, field.name), fv); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("%w: map key %s", ErrFormURLEncodedWrongValueType, rv.Type().Key())
+		}
+		iter := rv.MapRange()
+		for iter.Next() {
+			if err := encodeForm(values, formKey(key, iter.Key().String()), iter.Value()); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			values.Add(key, string(rv.Bytes()))
+			return nil
+		}
+		for i := 0; i < rv.Len(); i++ {
+			elem := rv.Index(i)
+			for elem.Kind() == reflect.Interface && !elem.IsNil() {
+				elem = elem.Elem()
+			}
+			if isFormScalar(elem.Type()) {
+				if err := encodeForm(values, key, elem); err != nil {
+					return err
+				}
+				continue
+			}
+			elemKey := formKey(key, strconv.Itoa(i))
+			written := len(values)
+			if err := encodeForm(values, elemKey, elem); err != nil {
+				return err
+			}
+			// Keep the index of an element that wrote nothing, so that
+			// the indexes stay dense
+			if len(values) == written {
+				values.Set(elemKey, "")
+			}
+		}
+	default:
+		s, err := formatFormScalar(rv)
+		if err != nil {
+			return fmt.Errorf("encoding form key %q: %w", key, err)
+		}
+		values.Add(key, s)
+	}
+	return nil
+}
+
+func formatFormScalar(rv reflect.Value) (string, error) {
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrFormURLEncodedWrongValueType, rv.Type())
+	}
+}
+
+// formChildren returns the names nested directly under key, sorted.
+func formChildren(values url.Values, key string) []string {
+	seen := map[string]bool{}
+	for k := range values {
+		var name string
+		if key == "" {
+			name, _, _ = strings.Cut(k, "[")
+		} else {
+			rest, ok := strings.CutPrefix(k, key+"[")
+			if !ok {
+				continue
+			}
+			if name, _, ok = strings.Cut(rest, "]"); !ok {
+				continue
+			}
+		}
+		seen[name] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// hasFormKey reports whether values hold key itself or keys nested under it.
+func hasFormKey(values url.Values, key string) bool {
+	if _, ok := values[key]; ok {
+		return true
+	}
+	for k := range values {
+		if strings.HasPrefix(k, key+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeForm(values url.Values, key string, rv reflect.Value) error {
+	if key != "" && !hasFormKey(values, key) {
+		return nil
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return decodeForm(values, key, rv.Elem())
+	}
+
+	if reflect.PointerTo(rv.Type()).Implements(textUnmarshalerType) {
+		text := values.Get(key)
+		if err := rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+			return fmt.Errorf("encoding form key %q: %w", key, err)
+		}
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		for _, field := range formFields(rv.Type()) {
+			if err := decodeForm(values, formKey(key, field.name), rv.FieldByIndex(field.index)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keyType := rv.Type().Key()
+		if keyType.Kind() != reflect.String {
+			return fmt.Errorf("%w: map key %s", ErrFormURLEncodedWrongValueType, keyType)
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.MakeMap(rv.Type()))
+		}
+		for _, name := range formChildren(values, key) {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := decodeForm(values, formKey(key, name), elem); err != nil {
+				return err
+			}
+			rv.SetMapIndex(reflect.ValueOf(name).Convert(keyType), elem)
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes([]byte(values.Get(key)))
+			return nil
+		}
+		if isFormScalar(rv.Type().Elem()) {
+			vals := values[key]
+			target := rv
+			if rv.Kind() == reflect.Slice {
+				target = reflect.MakeSlice(rv.Type(), len(vals), len(vals))
+			}
+			for i := 0; i < min(len(vals), target.Len()); i++ {
+				if err := decodeForm(url.Values{key: {vals[i]}}, key, target.Index(i)); err != nil {
+					return err
+				}
+			}
+			rv.Set(target)
+			return nil
+		}
+		// Indexes must run from 0 without gaps, so the length is bounded by
+		// the number of keys rather than by the largest index
+		names := formChildren(values, key)
+		length := len(names)
+		for _, name := range names {
+			index, err := strconv.Atoi(name)
+			if err != nil || index < 0 || strconv.Itoa(index) != name {
+				return fmt.Errorf("encoding form key %q: invalid index %q", key, name)
+			}
+			if index >= length {
+				return fmt.Errorf("encoding form key %q: sparse index %q", key, name)
+			}
+		}
+		target := rv
+		if rv.Kind() == reflect.Slice {
+			target = reflect.MakeSlice(rv.Type(), length, length)
+		}
+		for i := 0; i < min(length, target.Len()); i++ {
+			if err := decodeForm(values, formKey(key, strconv.Itoa(i)), target.Index(i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(target)
+	case reflect.Interface:
+		if rv.NumMethod() != 0 {
+			return fmt.Errorf("%w: %s", ErrFormURLEncodedWrongValueType, rv.Type())
+		}
+		switch vals := values[key]; {
+		case len(vals) == 1:
+			rv.Set(reflect.ValueOf(vals[0]))
+		case len(vals) > 1:
+			rv.Set(reflect.ValueOf(vals))
+		default:
+			nested := map[string]interface{}{}
+			if err := decodeForm(values, key, reflect.ValueOf(&nested).Elem()); err != nil {
+				return err
+			}
+			rv.Set(reflect.ValueOf(nested))
+		}
+	default:
+		if err := parseFormScalar(values.Get(key), rv); err != nil {
+			return fmt.Errorf("encoding form key %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func parseFormScalar(s string, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, rv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	default:
+		return fmt.Errorf("%w: %s", ErrFormURLEncodedWrongValueType, rv.Type())
+	}
+	return nil
+}
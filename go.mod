@@ -3,13 +3,17 @@ module github.com/aura-studio/encodingx
 go 1.24.1
 
 require (
+	github.com/andybalholm/brotli v1.2.6
 	github.com/aura-studio/magic v1.0.0
 	github.com/aura-studio/reflectx v1.0.0
 	github.com/aura-studio/style v1.0.1
 	github.com/gocarina/gocsv v0.0.0-20221105105431-c8ef78125b99
 	github.com/google/flatbuffers v22.10.26+incompatible
+	github.com/klauspost/compress v1.19.2
 	github.com/pelletier/go-toml/v2 v2.2.4
+	github.com/pierrec/lz4/v4 v4.1.31
 	github.com/vmihailenco/msgpack/v5 v5.4.1
+	golang.org/x/crypto v0.48.0
 	google.golang.org/protobuf v1.31.0
 	gopkg.in/yaml.v3 v3.0.1
 	pgregory.net/rapid v1.2.0
@@ -18,4 +22,5 @@ require (
 require (
 	github.com/stretchr/testify v1.8.4 // indirect
 	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	golang.org/x/sys v0.41.0 // indirect
 )
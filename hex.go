@@ -3,11 +3,13 @@ package encodingx
 import (
 	"bytes"
 	"compress/zlib"
+	"context"
 	"crypto/rand"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"io"
+	"slices"
 
 	"github.com/aura-studio/reflectx"
 )
@@ -44,13 +46,17 @@ func (Hex) Style() EncodingStyleType {
 	return EncodingStyleBytes
 }
 
-func (Hex) Marshal(v any) ([]byte, error) {
+func (h Hex) Marshal(v any) ([]byte, error) {
+	return h.AppendMarshal(nil, v)
+}
+
+func (Hex) AppendMarshal(dst []byte, v any) ([]byte, error) {
 	data, err := toBytes(v)
 	if err != nil {
 		return nil, ErrHexWrongValueType
 	}
 
-	return []byte(hex.EncodeToString(data)), nil
+	return hex.AppendEncode(dst, data), nil
 }
 
 func (Hex) Unmarshal(data []byte, v any) error {
@@ -62,6 +68,14 @@ func (Hex) Unmarshal(data []byte, v any) error {
 	return fromBytes(decoded, v)
 }
 
+func (Hex) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(nopWriteCloser{hex.NewEncoder(w)}, ErrHexWrongValueType)
+}
+
+func (Hex) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(hex.NewDecoder(r), ErrHexWrongValueType)
+}
+
 func (h Hex) Reverse() Encoding {
 	return h
 }
@@ -69,44 +83,50 @@ func (h Hex) Reverse() Encoding {
 // ============================================================================
 // HexTier - Hex encoding with tier padding (no compression)
 // Format: [4 bytes length (big-endian)] + [raw data] + [zero padding]
-// Tiers: 8, 16, 32, 64, 128, 256, ... bytes (power of 2)
+// Tiers: 8, 16, 32, 64, 128, 256, ... bytes (power of 2), or as set by
+// WithTierPolicy
 // ============================================================================
 
-type HexTier struct{}
+type HexTier struct {
+	options
+}
 
-func NewHexTier() *HexTier {
-	return new(HexTier)
+// NewHexTier creates a HexTier encoding, honoring WithName and
+// WithTierPolicy.
+func NewHexTier(opts ...HexTierOption) *HexTier {
+	return &HexTier{
+		options: newOptions(opts, HexTierOption.applyHexTier),
+	}
 }
 
 func (h HexTier) String() string {
-	return reflectx.TypeName(h)
+	return h.nameOr(reflectx.TypeName(h))
 }
 
 func (HexTier) Style() EncodingStyleType {
 	return EncodingStyleBytes
 }
 
-func (HexTier) Marshal(v any) ([]byte, error) {
+func (h HexTier) Marshal(v any) ([]byte, error) {
+	return h.AppendMarshal(nil, v)
+}
+
+func (h HexTier) AppendMarshal(dst []byte, v any) ([]byte, error) {
 	data, err := toBytes(v)
 	if err != nil {
 		return nil, ErrHexWrongValueType
 	}
 
-	tierSize := findTierSize(len(data) + 4)
-	output := make([]byte, tierSize)
-	binary.BigEndian.PutUint32(output[:4], uint32(len(data)))
-	copy(output[4:], data)
-
-	return []byte(hex.EncodeToString(output)), nil
+	return appendTierHex(dst, data, h.tierPolicyOr(defaultTierPolicy)), nil
 }
 
-func (HexTier) Unmarshal(data []byte, v any) error {
+func (h HexTier) Unmarshal(data []byte, v any) error {
 	decoded, err := hex.DecodeString(string(data))
 	if err != nil {
 		return err
 	}
 
-	if !isTierSize(len(decoded)) {
+	if !h.tierPolicyOr(defaultTierPolicy).IsTierSize(len(decoded)) {
 		return ErrHexInvalidData
 	}
 
@@ -129,65 +149,87 @@ func (h HexTier) Reverse() Encoding {
 // ============================================================================
 // HexTierRand - Hex encoding with random padding and rolling XOR obfuscation
 // Format: [4 bytes random key] + [4 bytes XORed length] + [XORed data] + [random padding]
-// Tiers: 16, 32, 64, 128, 256, ... bytes (power of 2, min 16 for key+length)
+// Tiers: 16, 32, 64, 128, 256, ... bytes (power of 2, min 16 for key+length),
+// or as set by WithTierPolicy
 // Each byte uses different XOR key (rolling), making output appear fully random
+// This is obfuscation, not encryption: the key travels with the data. Use
+// HexTierSealed, AESGCM or ChaCha20Poly1305 where the data must stay
+// confidential.
 // ============================================================================
 
-type HexTierRand struct{}
+type HexTierRand struct {
+	options
+}
 
-func NewHexTierRand() *HexTierRand {
-	return new(HexTierRand)
+// NewHexTierRand creates a HexTierRand encoding, honoring WithName and
+// WithTierPolicy.
+func NewHexTierRand(opts ...HexTierOption) *HexTierRand {
+	return &HexTierRand{
+		options: newOptions(opts, HexTierOption.applyHexTier),
+	}
 }
 
 func (h HexTierRand) String() string {
-	return reflectx.TypeName(h)
+	return h.nameOr(reflectx.TypeName(h))
 }
 
 func (HexTierRand) Style() EncodingStyleType {
 	return EncodingStyleBytes
 }
 
-func (HexTierRand) Marshal(v any) ([]byte, error) {
+func (h HexTierRand) Marshal(v any) ([]byte, error) {
+	return h.AppendMarshal(nil, v)
+}
+
+func (h HexTierRand) AppendMarshal(dst []byte, v any) ([]byte, error) {
 	data, err := toBytes(v)
 	if err != nil {
 		return nil, ErrHexWrongValueType
 	}
 
-	// Generate 4-byte random key
-	key := make([]byte, 4)
-	rand.Read(key)
-
-	tierSize := findTierSizeMin(len(data)+8, 16) // min 16 for key(4)+len(4)+data
-	output := make([]byte, tierSize)
-
-	// Fill entire buffer with random bytes first (random padding)
-	rand.Read(output)
+	tierSize := h.tierPolicyOr(randTierPolicy).TierSize(len(data) + 8) // key(4)+len(4)+data
+	dst = slices.Grow(dst, tierSize*2)
 
 	// First 4 bytes: random key (unobfuscated)
-	copy(output[:4], key)
+	var head [8]byte
+	rand.Read(head[:4])
+	key := head[:4]
 
 	// Next 4 bytes: length XORed with rolling key
-	lenBytes := make([]byte, 4)
-	binary.BigEndian.PutUint32(lenBytes, uint32(len(data)))
+	binary.BigEndian.PutUint32(head[4:], uint32(len(data)))
 	for i := 0; i < 4; i++ {
-		output[4+i] = lenBytes[i] ^ key[i]
+		head[4+i] ^= key[i]
+	}
+	dst = hex.AppendEncode(dst, head[:])
+
+	// XOR data with rolling key, a chunk at a time
+	var chunk [64]byte
+	for offset := 0; offset < len(data); offset += len(chunk) {
+		n := copy(chunk[:], data[offset:])
+		for i := 0; i < n; i++ {
+			chunk[i] ^= key[(offset+i)%4]
+		}
+		dst = hex.AppendEncode(dst, chunk[:n])
 	}
 
-	// XOR data with rolling key
-	for i, b := range data {
-		output[8+i] = b ^ key[i%4]
+	// Random padding
+	for padding := tierSize - 8 - len(data); padding > 0; {
+		n := min(padding, len(chunk))
+		rand.Read(chunk[:n])
+		dst = hex.AppendEncode(dst, chunk[:n])
+		padding -= n
 	}
 
-	return []byte(hex.EncodeToString(output)), nil
+	return dst, nil
 }
 
-func (HexTierRand) Unmarshal(data []byte, v any) error {
+func (h HexTierRand) Unmarshal(data []byte, v any) error {
 	decoded, err := hex.DecodeString(string(data))
 	if err != nil {
 		return err
 	}
 
-	if !isTierSize(len(decoded)) {
+	if !h.tierPolicyOr(randTierPolicy).IsTierSize(len(decoded)) {
 		return ErrHexInvalidData
 	}
 
@@ -222,113 +264,252 @@ func (h HexTierRand) Reverse() Encoding {
 	return h
 }
 
-// findTierSizeMin finds the smallest tier (power of 2) >= minTier that can hold the payload
-func findTierSizeMin(payloadLen, minTier int) int {
-	tierSize := minTier
-	for tierSize < payloadLen {
-		tierSize *= 2
+// isFrame reports whether decoded is a frame AppendMarshal writes: one
+// whose length fits and which is padded to exactly the tier of its length.
+func (h HexTierRand) isFrame(decoded []byte) bool {
+	if len(decoded) < 8 {
+		return false
+	}
+	var length [4]byte
+	for i := range length {
+		length[i] = decoded[4+i] ^ decoded[i]
 	}
-	return tierSize
+	dataLen := int64(binary.BigEndian.Uint32(length[:]))
+	return dataLen <= int64(len(decoded)-8) &&
+		h.tierPolicyOr(randTierPolicy).TierSize(int(dataLen)+8) == len(decoded)
 }
 
 // ============================================================================
 // HexZlib - Hex encoding with zlib compression and tier padding
 // Format: [4 bytes length (big-endian)] + [zlib compressed data] + [zero padding]
-// Tiers: 8, 16, 32, 64, 128, 256, ... bytes (power of 2)
+// Tiers: 8, 16, 32, 64, 128, 256, ... bytes (power of 2), or as set by
+// WithTierPolicy
+// Same output as the chain "Zlib|HexTier"
+// Unmarshal yields at most DefaultMaxDecompressedSize bytes unless set
+// otherwise by WithMaxDecompressedSize
 // ============================================================================
 
-type HexZlib struct{}
+type HexZlib struct {
+	options
+}
 
-func NewHexZlib() *HexZlib {
-	return new(HexZlib)
+// NewHexZlib creates a HexZlib encoding, honoring WithName, WithLevel,
+// WithDictionary, WithTierPolicy and the decompression limits.
+func NewHexZlib(opts ...HexZlibOption) *HexZlib {
+	return &HexZlib{
+		options: newOptions(opts, HexZlibOption.applyHexZlib),
+	}
 }
 
 func (h HexZlib) String() string {
-	return reflectx.TypeName(h)
+	return h.nameOr(reflectx.TypeName(h))
 }
 
 func (HexZlib) Style() EncodingStyleType {
 	return EncodingStyleBytes
 }
 
-func (HexZlib) Marshal(v any) ([]byte, error) {
+func (h HexZlib) Marshal(v any) ([]byte, error) {
+	return h.AppendMarshal(nil, v)
+}
+
+func (h HexZlib) AppendMarshal(dst []byte, v any) ([]byte, error) {
+	return h.appendMarshal(context.Background(), dst, v)
+}
+
+// MarshalContext is like Marshal but stops compressing once ctx is done.
+func (h HexZlib) MarshalContext(ctx context.Context, v any) ([]byte, error) {
+	return h.appendMarshal(ctx, nil, v)
+}
+
+func (h HexZlib) appendMarshal(ctx context.Context, dst []byte, v any) ([]byte, error) {
 	data, err := toBytes(v)
 	if err != nil {
 		return nil, ErrHexWrongValueType
 	}
 
-	// Compress
-	var buf bytes.Buffer
-	w := zlib.NewWriter(&buf)
-	w.Write(data)
-	w.Close()
-	compressed := buf.Bytes()
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf, err = zlibFormat.appendCompress(ctx, *buf, data, h.levelOr(zlib.DefaultCompression), h.dictionary())
+	if err != nil {
+		return nil, err
+	}
 
-	tierSize := findTierSize(len(compressed) + 4)
-	output := make([]byte, tierSize)
-	binary.BigEndian.PutUint32(output[:4], uint32(len(compressed)))
-	copy(output[4:], compressed)
+	return HexTier{options: h.options}.AppendMarshal(dst, *buf)
+}
 
-	return []byte(hex.EncodeToString(output)), nil
+func (h HexZlib) Unmarshal(data []byte, v any) error {
+	return h.UnmarshalContext(context.Background(), data, v)
 }
 
-func (HexZlib) Unmarshal(data []byte, v any) error {
-	decoded, err := hex.DecodeString(string(data))
-	if err != nil {
+// UnmarshalContext is like Unmarshal but stops inflating once ctx is done.
+func (h HexZlib) UnmarshalContext(ctx context.Context, data []byte, v any) error {
+	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	if !isTierSize(len(decoded)) {
-		return ErrHexInvalidData
+	var compressed Bytes
+	if err := (HexTier{options: h.options}).Unmarshal(data, &compressed); err != nil {
+		return err
 	}
 
-	if len(decoded) < 4 {
-		return ErrHexInvalidData
+	decompressed, err := zlibFormat.decompress(ctx, compressed.Data, h.dictionary(), h.decompressLimits())
+	if err != nil {
+		return err
 	}
 
-	compressedLen := int(binary.BigEndian.Uint32(decoded[:4]))
-	if compressedLen > len(decoded)-4 {
-		return ErrHexInvalidData
+	return fromBytes(decompressed, v)
+}
+
+// NewEncoder compresses on the fly. Only the compressed payload is held
+// back until Close, because the frame starts with its length.
+func (h HexZlib) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(newHexZlibWriter(w, h.levelOr(zlib.DefaultCompression), h.dictionary(), h.tierPolicyOr(defaultTierPolicy)), ErrHexWrongValueType)
+}
+
+// NewDecoder inflates on the fly and validates the tier padding once the
+// compressed payload is exhausted.
+func (h HexZlib) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(newHexZlibReader(r, h.dictionary(), h.decompressLimits(), h.tierPolicyOr(defaultTierPolicy)), ErrHexWrongValueType)
+}
+
+func (h HexZlib) Reverse() Encoding {
+	return h
+}
+
+type hexZlibWriter struct {
+	w      io.Writer
+	buf    bytes.Buffer
+	zw     compressWriter
+	policy TierPolicy
+	err    error
+}
+
+func newHexZlibWriter(w io.Writer, level int, dict []byte, policy TierPolicy) *hexZlibWriter {
+	hw := &hexZlibWriter{w: w, policy: policy}
+	hw.zw, hw.err = zlibFormat.newWriter(&hw.buf, level, dict)
+	return hw
+}
+
+func (hw *hexZlibWriter) Write(p []byte) (int, error) {
+	if hw.err != nil {
+		return 0, hw.err
 	}
+	return hw.zw.Write(p)
+}
 
-	// Decompress
-	r, err := zlib.NewReader(bytes.NewReader(decoded[4 : 4+compressedLen]))
-	if err != nil {
+func (hw *hexZlibWriter) Close() error {
+	if hw.err != nil {
+		return hw.err
+	}
+	if err := hw.zw.Close(); err != nil {
 		return err
 	}
-	defer r.Close()
+	_, err := hw.w.Write(appendTierHex(nil, hw.buf.Bytes(), hw.policy))
+	return err
+}
 
-	decompressed, err := io.ReadAll(r)
-	if err != nil {
-		return err
+type hexZlibReader struct {
+	frame  *countingReader
+	dict   []byte
+	limits decompressLimits
+	policy TierPolicy
+	zr     io.ReadCloser
+	r      io.Reader
+	err    error
+}
+
+func newHexZlibReader(r io.Reader, dict []byte, limits decompressLimits, policy TierPolicy) *hexZlibReader {
+	return &hexZlibReader{
+		frame:  &countingReader{r: hex.NewDecoder(r)},
+		dict:   dict,
+		limits: limits,
+		policy: policy,
 	}
+}
 
-	return fromBytes(decompressed, v)
+func (hr *hexZlibReader) Read(p []byte) (int, error) {
+	if hr.err != nil {
+		return 0, hr.err
+	}
+	if hr.zr == nil {
+		header := make([]byte, 4)
+		if _, err := io.ReadFull(hr.frame, header); err != nil {
+			hr.err = invalidIfTruncated(err)
+			return 0, hr.err
+		}
+		compressedLen := int64(binary.BigEndian.Uint32(header))
+		hr.zr, hr.err = zlibFormat.newReader(io.LimitReader(hr.frame, compressedLen), hr.dict)
+		if hr.err != nil {
+			hr.err = invalidIfTruncated(hr.err)
+			return 0, hr.err
+		}
+		hr.r = newLimitReader(hr.zr, hr.limits, func() int64 { return hr.frame.n }, nil)
+	}
+	n, err := hr.r.Read(p)
+	if err == io.EOF {
+		// Skip the padding, the whole frame must still be a tier
+		if _, err := io.Copy(io.Discard, hr.frame); err != nil {
+			hr.err = err
+			return n, err
+		}
+		if !hr.policy.IsTierSize(int(hr.frame.n)) {
+			hr.err = ErrHexInvalidData
+			return n, hr.err
+		}
+	} else if err != nil {
+		err = invalidIfTruncated(err)
+	}
+	hr.err = err
+	return n, err
 }
 
-func (h HexZlib) Reverse() Encoding {
-	return h
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// invalidIfTruncated maps a frame ending too early to ErrHexInvalidData,
+// as Unmarshal reports it.
+func invalidIfTruncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrHexInvalidData
+	}
+	return err
 }
 
 // ============================================================================
 // Helper functions
 // ============================================================================
 
-// findTierSize finds the smallest tier (power of 2, >= 8) that can hold the payload
-func findTierSize(payloadLen int) int {
-	tierSize := 8
-	for tierSize < payloadLen {
-		tierSize *= 2
-	}
-	return tierSize
-}
+// Default tier policies: power of 2 from 8 bytes, or from 16 for HexTierRand
+var (
+	defaultTierPolicy TierPolicy = powerOfTwoTiers{min: 8}
+	randTierPolicy    TierPolicy = powerOfTwoTiers{min: 16}
+)
 
-// isTierSize checks if size is a valid tier (power of 2, >= 8)
-func isTierSize(size int) bool {
-	if size < 8 {
-		return false
+// appendTierHex appends the hex form of
+// [4 bytes length (big-endian)] + [payload] + [zero padding]
+// without building the frame itself
+func appendTierHex(dst, payload []byte, policy TierPolicy) []byte {
+	tierSize := policy.TierSize(len(payload) + 4)
+	dst = slices.Grow(dst, tierSize*2)
+
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(payload)))
+	dst = hex.AppendEncode(dst, header[:])
+	dst = hex.AppendEncode(dst, payload)
+	for i := 4 + len(payload); i < tierSize; i++ {
+		dst = append(dst, '0', '0')
 	}
-	return size&(size-1) == 0
+	return dst
 }
 
 // toBytes converts value to []byte
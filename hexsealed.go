@@ -0,0 +1,181 @@
+package encodingx
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"slices"
+
+	"github.com/aura-studio/reflectx"
+	"golang.org/x/crypto/chacha20"
+)
+
+// ============================================================================
+// HexTierSealed - Hex encoding with tier padding, sealed under a secret
+// Format: [12 bytes random nonce] + [encrypted: 4 bytes length + data + zero padding] + [12 bytes MAC]
+// Tiers: 32, 64, 128, 256, ... bytes (power of 2, min 32 for nonce+length+MAC),
+// or as set by WithTierPolicy
+// HKDF-SHA256 derives a ChaCha20 key and an HMAC-SHA256 key from the secret
+// and the nonce of each message; the MAC is truncated to 12 bytes. Without
+// the secret the output is random, unlike HexTierRand, and altered data
+// fails with ErrAuthentication. Like AESGCM, it is not registered by
+// default.
+// ============================================================================
+
+const (
+	sealedNonceSize = 12
+	sealedMACSize   = 12
+	sealedOverhead  = sealedNonceSize + 4 + sealedMACSize
+	sealedInfo      = "encodingx HexTierSealed v1"
+)
+
+type HexTierSealed struct {
+	options
+	// secret sits behind a pointer, which keeps HexTierSealed comparable
+	// with ==. It is nil without a secret.
+	secret *[]byte
+}
+
+// NewHexTierSealed creates a HexTierSealed encoding sealing under a copy of
+// secret, which should be 32 random bytes, honoring WithName,
+// WithTierPolicy and WithLegacyDecode.
+func NewHexTierSealed(secret []byte, opts ...HexTierSealedOption) *HexTierSealed {
+	h := &HexTierSealed{
+		options: newOptions(opts, HexTierSealedOption.applyHexTierSealed),
+	}
+	if len(secret) > 0 {
+		copied := slices.Clone(secret)
+		h.secret = &copied
+	}
+	return h
+}
+
+func (h HexTierSealed) String() string {
+	return h.nameOr(reflectx.TypeName(h))
+}
+
+func (HexTierSealed) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (h HexTierSealed) Marshal(v any) ([]byte, error) {
+	return h.AppendMarshal(nil, v)
+}
+
+func (h HexTierSealed) AppendMarshal(dst []byte, v any) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrHexWrongValueType
+	}
+	if h.secret == nil {
+		return nil, ErrKeyNotFound
+	}
+
+	tierSize := h.tierPolicyOr(defaultTierPolicy).TierSize(sealedOverhead + len(data))
+	buf := getBuffer()
+	defer putBuffer(buf)
+	*buf = slices.Grow((*buf)[:0], tierSize)[:tierSize]
+	frame := *buf
+	clear(frame)
+
+	nonce := frame[:sealedNonceSize]
+	rand.Read(nonce)
+	body := frame[sealedNonceSize : tierSize-sealedMACSize]
+	binary.BigEndian.PutUint32(body, uint32(len(data)))
+	copy(body[4:], data)
+
+	stream, mac, err := h.derive(nonce)
+	if err != nil {
+		return nil, err
+	}
+	stream.XORKeyStream(body, body)
+	mac.Write(frame[:tierSize-sealedMACSize])
+	copy(frame[tierSize-sealedMACSize:], mac.Sum(nil))
+
+	return hex.AppendEncode(dst, frame), nil
+}
+
+// Unmarshal fails with ErrAuthentication if data was not sealed under the
+// secret, or was altered since. With WithLegacyDecode, data that is not a
+// sealed frame, or fails authentication but is a frame as HexTierRand
+// writes it, is then read as HexTierRand.
+func (h HexTierSealed) Unmarshal(data []byte, v any) error {
+	decoded, err := hex.DecodeString(string(data))
+	if err != nil {
+		return err
+	}
+	if h.secret == nil {
+		return ErrKeyNotFound
+	}
+
+	opened, err := h.open(decoded)
+	if err != nil {
+		if h.legacyDecode && h.isLegacy(decoded, err) {
+			return HexTierRand{options: h.options}.Unmarshal(data, v)
+		}
+		return err
+	}
+	return fromBytes(opened, v)
+}
+
+// isLegacy reports whether decoded, which failed to open with err, is to be
+// read as HexTierRand. Sealed data that was altered looks random, so it is
+// all but never a HexTierRand frame padded to just its tier.
+func (h HexTierSealed) isLegacy(decoded []byte, err error) bool {
+	switch {
+	case errors.Is(err, ErrHexInvalidData):
+		return true
+	case errors.Is(err, ErrAuthentication):
+		return HexTierRand{options: h.options}.isFrame(decoded)
+	default:
+		return false
+	}
+}
+
+func (h HexTierSealed) Reverse() Encoding {
+	return h
+}
+
+func (h HexTierSealed) open(decoded []byte) ([]byte, error) {
+	if !h.tierPolicyOr(defaultTierPolicy).IsTierSize(len(decoded)) || len(decoded) < sealedOverhead {
+		return nil, ErrHexInvalidData
+	}
+	tierSize := len(decoded)
+	nonce := decoded[:sealedNonceSize]
+	stream, mac, err := h.derive(nonce)
+	if err != nil {
+		return nil, err
+	}
+	mac.Write(decoded[:tierSize-sealedMACSize])
+	if !hmac.Equal(mac.Sum(nil)[:sealedMACSize], decoded[tierSize-sealedMACSize:]) {
+		return nil, ErrAuthentication
+	}
+
+	body := decoded[sealedNonceSize : tierSize-sealedMACSize]
+	stream.XORKeyStream(body, body)
+	dataLen := int(binary.BigEndian.Uint32(body[:4]))
+	if dataLen > len(body)-4 {
+		return nil, ErrHexInvalidData
+	}
+	return body[4 : 4+dataLen], nil
+}
+
+// derive returns the keystream and the MAC of the message sealed under
+// nonce.
+func (h HexTierSealed) derive(nonce []byte) (*chacha20.Cipher, hash.Hash, error) {
+	keys, err := hkdf.Key(sha256.New, *h.secret, nonce, sealedInfo, 2*chacha20.KeySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Every message has keys of its own, so a zero nonce is safe here
+	stream, err := chacha20.NewUnauthenticatedCipher(keys[:chacha20.KeySize], make([]byte, chacha20.NonceSize))
+	if err != nil {
+		return nil, nil, err
+	}
+	return stream, hmac.New(sha256.New, keys[chacha20.KeySize:]), nil
+}
@@ -0,0 +1,137 @@
+package encodingx
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrHMACWrongValueType  = errors.New("encoding HMAC converts on wrong type value")
+	ErrHMACUnsupportedHash = errors.New("encoding HMAC unsupported hash")
+)
+
+// ============================================================================
+// HMAC - data followed by its HMAC, for tamper detection without secrecy
+// Format: [1 byte key ID length] + [key ID] + [data] + [MAC]
+// The MAC covers the key ID and the data. WithHash picks SHA-256, the
+// default, SHA-384 or SHA-512, with 32, 48 or 64 byte MACs.
+// Like AESGCM, HMAC needs keys and is not registered by default.
+// ============================================================================
+
+type HMAC struct {
+	options
+	keys KeyProvider
+}
+
+// NewHMAC creates an HMAC encoding signing with the current key of keys,
+// honoring WithName and WithHash. Keys should be at least as long as the
+// MAC.
+func NewHMAC(keys KeyProvider, opts ...HMACOption) *HMAC {
+	return &HMAC{
+		options: newOptions(opts, HMACOption.applyHMAC),
+		keys:    keys,
+	}
+}
+
+func (h HMAC) String() string {
+	return h.nameOr(reflectx.TypeName(h))
+}
+
+func (HMAC) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (h HMAC) Marshal(v interface{}) ([]byte, error) {
+	return h.AppendMarshal(nil, v)
+}
+
+func (h HMAC) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrHMACWrongValueType
+	}
+	newHash, err := h.newHash()
+	if err != nil {
+		return nil, err
+	}
+	if h.keys == nil {
+		return nil, ErrKeyNotFound
+	}
+	id, key, err := h.keys.CurrentKey()
+	if err != nil {
+		return nil, err
+	}
+
+	start := len(dst)
+	dst, err = appendKeyID(dst, id)
+	if err != nil {
+		return nil, err
+	}
+	dst = append(dst, data...)
+	mac := hmac.New(newHash, key)
+	mac.Write(dst[start:])
+	return mac.Sum(dst), nil
+}
+
+// Unmarshal strips the MAC off data once it verifies, and fails with
+// ErrAuthentication if data was not signed with one of the keys, or was
+// altered since.
+func (h HMAC) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		newHash, err := h.newHash()
+		if err != nil {
+			return err
+		}
+		if h.keys == nil {
+			return ErrKeyNotFound
+		}
+		id, header, rest, ok := readKeyID(data)
+		if !ok {
+			return ErrAuthentication
+		}
+		key, err := h.keys.Key(id)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrAuthentication, err)
+		}
+
+		mac := hmac.New(newHash, key)
+		if len(rest) < mac.Size() {
+			return ErrAuthentication
+		}
+		signed := data[:len(header)+len(rest)-mac.Size()]
+		mac.Write(signed)
+		if !hmac.Equal(mac.Sum(nil), data[len(signed):]) {
+			return ErrAuthentication
+		}
+		v.Data = slices.Clone(signed[len(header):])
+		return nil
+	default:
+		return ErrHMACWrongValueType
+	}
+}
+
+func (h HMAC) Reverse() Encoding {
+	return h
+}
+
+func (h HMAC) newHash() (func() hash.Hash, error) {
+	switch h.hash {
+	case 0, crypto.SHA256:
+		return sha256.New, nil
+	case crypto.SHA384:
+		return sha512.New384, nil
+	case crypto.SHA512:
+		return sha512.New, nil
+	default:
+		return nil, ErrHMACUnsupportedHash
+	}
+}
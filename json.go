@@ -1,35 +1,44 @@
 package encodingx
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
+	"io"
 
 	"github.com/aura-studio/reflectx"
 )
 
 var (
 	ErrJSONWrongValueType = errors.New("encoding JSON converts on wrong type value")
+	ErrJSONTrailingData   = errors.New("encoding JSON trailing data after top-level value")
 )
 
-type JSON struct{}
+type JSON struct {
+	options
+}
 
 func init() {
 	register(NewJSON())
 }
 
-func NewJSON() *JSON {
-	return new(JSON)
+// NewJSON creates a JSON encoding, honoring WithName, WithIndent, WithPrefix
+// and WithDisallowUnknownFields.
+func NewJSON(opts ...JSONOption) *JSON {
+	return &JSON{
+		options: newOptions(opts, JSONOption.applyJSON),
+	}
 }
 
 func (json JSON) String() string {
-	return reflectx.TypeName(json)
+	return json.nameOr(reflectx.TypeName(json))
 }
 
 func (json JSON) Style() EncodingStyleType {
 	return EncodingStyleStruct
 }
 
-func (JSON) Marshal(v interface{}) ([]byte, error) {
+func (j JSON) Marshal(v interface{}) ([]byte, error) {
 	switch v := v.(type) {
 	case []byte:
 		return v, nil
@@ -38,20 +47,77 @@ func (JSON) Marshal(v interface{}) ([]byte, error) {
 	case *Bytes:
 		return v.Data, nil
 	default:
+		if j.indented() {
+			return json.MarshalIndent(v, j.prefix, j.indent)
+		}
 		return json.Marshal(v)
 	}
 }
 
-func (JSON) Unmarshal(data []byte, v interface{}) error {
+func (j JSON) Unmarshal(data []byte, v interface{}) error {
 	switch v := v.(type) {
 	case *Bytes:
 		v.Data = data
 		return nil
 	default:
+		if j.disallowUnknownFields {
+			return j.unmarshalStrict(data, v)
+		}
 		return json.Unmarshal(data, v)
 	}
 }
 
+// unmarshalStrict goes through a json.Decoder, the only way to reject
+// unknown fields, and checks for trailing data as json.Unmarshal does.
+func (JSON) unmarshalStrict(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return ErrJSONTrailingData
+	}
+	return nil
+}
+
+// NewEncoder returns an Encoder writing one JSON value per line, []byte
+// and Bytes values are written through unchanged as in Marshal.
+func (j JSON) NewEncoder(w io.Writer) Encoder {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent(j.prefix, j.indent)
+	return streamEncoder{
+		encode: func(v interface{}) error {
+			if ok, err := writeBytes(w, v); ok {
+				return err
+			}
+			return encoder.Encode(v)
+		},
+	}
+}
+
+// NewDecoder returns a Decoder reading successive JSON values, decoding
+// into *Bytes takes the rest of the stream as in Unmarshal.
+func (j JSON) NewDecoder(r io.Reader) Decoder {
+	decoder := json.NewDecoder(r)
+	if j.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	return streamDecoder{
+		decode: func(v interface{}) error {
+			if v, ok := v.(*Bytes); ok {
+				data, err := io.ReadAll(io.MultiReader(decoder.Buffered(), r))
+				if err != nil {
+					return err
+				}
+				v.Data = data
+				return nil
+			}
+			return decoder.Decode(v)
+		},
+	}
+}
+
 func (json JSON) Reverse() Encoding {
 	return json
 }
@@ -0,0 +1,185 @@
+package encodingx
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	ErrJWKSInvalidKey = errors.New("encoding JWKS invalid key")
+)
+
+// JWKS is a JWTKeySet holding the keys of a JSON Web Key Set document
+// (RFC 7517), such as an identity provider publishes.
+type JWKS struct {
+	keys []jwk
+}
+
+type jwk struct {
+	kid string
+	alg string
+	key crypto.PublicKey
+}
+
+// jwkJSON holds the members of a JSON Web Key that JWT makes use of.
+type jwkJSON struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// ParseJWKS parses a JWKS document holding RSA, P-256 EC, Ed25519 OKP and
+// symmetric ("oct") keys. Keys of other types or curves, and encryption
+// keys, are skipped, as RFC 7517 asks; a malformed key fails with
+// ErrJWKSInvalidKey.
+func ParseJWKS(data []byte) (*JWKS, error) {
+	var document struct {
+		Keys []jwkJSON `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	set := new(JWKS)
+	for index, raw := range document.Keys {
+		if raw.Use == "enc" {
+			continue
+		}
+		key, err := raw.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("%w: key %d: %w", ErrJWKSInvalidKey, index, err)
+		}
+		if key != nil {
+			set.keys = append(set.keys, jwk{kid: raw.Kid, alg: raw.Alg, key: key})
+		}
+	}
+	return set, nil
+}
+
+// Len returns the number of keys held, skipped keys aside.
+func (s *JWKS) Len() int {
+	return len(s.keys)
+}
+
+// VerificationKey returns the key under kid that suits alg. A token without
+// kid matches when exactly one key suits alg.
+func (s *JWKS) VerificationKey(kid, alg string) (crypto.PublicKey, error) {
+	var found []crypto.PublicKey
+	for _, k := range s.keys {
+		if (kid != "" && k.kid != kid) || (k.alg != "" && k.alg != alg) || !jwkSuits(k.key, alg) {
+			continue
+		}
+		if kid != "" {
+			return k.key, nil
+		}
+		found = append(found, k.key)
+	}
+	if len(found) != 1 {
+		return nil, fmt.Errorf("%w: kid %q for %s", ErrKeyNotFound, kid, alg)
+	}
+	return found[0], nil
+}
+
+// jwkSuits reports whether key is of the type alg verifies with.
+func jwkSuits(key crypto.PublicKey, alg string) bool {
+	switch key.(type) {
+	case []byte:
+		return alg == JWTAlgHS256
+	case *rsa.PublicKey:
+		return alg == JWTAlgRS256
+	case *ecdsa.PublicKey:
+		return alg == JWTAlgES256
+	case ed25519.PublicKey:
+		return alg == JWTAlgEdDSA
+	default:
+		return false
+	}
+}
+
+// publicKey returns the key k holds, or nil for a type JWT cannot use.
+func (k jwkJSON) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := jwkDecode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := jwkDecode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if len(n) == 0 || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("bad RSA modulus or exponent")
+		}
+		exponent := new(big.Int).SetBytes(e)
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, nil
+		}
+		x, err := jwkDecode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := jwkDecode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("bad P-256 coordinates")
+		}
+		// ecdh checks that the point is on the curve
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdh.P256().NewPublicKey(point); err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, nil
+		}
+		x, err := jwkDecode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("bad Ed25519 key size")
+		}
+		return ed25519.PublicKey(x), nil
+	case "oct":
+		secret, err := jwkDecode(k.K)
+		if err != nil {
+			return nil, err
+		}
+		if len(secret) == 0 {
+			return nil, errors.New("empty secret")
+		}
+		return secret, nil
+	default:
+		return nil, nil
+	}
+}
+
+func jwkDecode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
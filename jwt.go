@@ -0,0 +1,336 @@
+package encodingx
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrJWTWrongValueType       = errors.New("encoding JWT converts on wrong type value")
+	ErrJWTMalformed            = errors.New("encoding JWT malformed token")
+	ErrJWTUnsupportedAlgorithm = errors.New("encoding JWT unsupported algorithm")
+	ErrJWTExpired              = errors.New("encoding JWT token expired")
+	ErrJWTNotYetValid          = errors.New("encoding JWT token not yet valid")
+	ErrJWTInvalidIssuer        = errors.New("encoding JWT invalid issuer")
+	ErrJWTInvalidAudience      = errors.New("encoding JWT invalid audience")
+)
+
+// The JWS algorithms JWT signs and verifies with (RFC 7518, RFC 8037).
+const (
+	JWTAlgHS256 = "HS256"
+	JWTAlgRS256 = "RS256"
+	JWTAlgES256 = "ES256"
+	JWTAlgEdDSA = "EdDSA"
+)
+
+// JWTKeySet supplies the keys JWT verifies tokens with. PublicKeys and JWKS
+// are JWTKeySets.
+type JWTKeySet interface {
+	// VerificationKey returns the key for a token signed with alg under
+	// kid, which may be empty: a []byte secret for HS256, *rsa.PublicKey
+	// for RS256, *ecdsa.PublicKey on P-256 for ES256 and ed25519.PublicKey
+	// for EdDSA.
+	VerificationKey(kid, alg string) (crypto.PublicKey, error)
+}
+
+// VerificationKey makes PublicKeys a JWTKeySet, looking keys up by kid.
+func (p PublicKeys) VerificationKey(kid, _ string) (crypto.PublicKey, error) {
+	return p.PublicKey(kid)
+}
+
+// ============================================================================
+// JWT - claims as a compact JWS (RFC 7515, RFC 7519)
+// Format: base64url(header) + "." + base64url(claims) + "." + base64url(signature)
+// Marshal signs with WithSigningKey. Unmarshal verifies the signature with
+// the key set, then the exp and nbf claims, with WithLeeway, and the iss and
+// aud claims against WithIssuer and WithAudience, before it fills v.
+// Like HMAC, JWT needs keys and is not registered by default.
+// ============================================================================
+
+type JWT struct {
+	options
+	keys *jwtKeys
+}
+
+// jwtKeys holds the JWTKeySet of a JWT behind a pointer, which keeps JWT
+// comparable with ==, as PublicKeys is a map.
+type jwtKeys struct {
+	set JWTKeySet
+}
+
+// NewJWT creates a JWT encoding verifying with keys, honoring WithName,
+// WithSigningKey, WithIssuer, WithAudience, WithLeeway and
+// WithDisallowUnknownFields. Without keys, it verifies with the signing key.
+func NewJWT(keys JWTKeySet, opts ...JWTOption) *JWT {
+	j := &JWT{
+		options: newOptions(opts, JWTOption.applyJWT),
+	}
+	if keys != nil {
+		j.keys = &jwtKeys{set: keys}
+	}
+	return j
+}
+
+func (j JWT) String() string {
+	return j.nameOr(reflectx.TypeName(j))
+}
+
+func (JWT) Style() EncodingStyleType {
+	return EncodingStyleStruct
+}
+
+// jwtHeader is the JOSE header of a token.
+type jwtHeader struct {
+	Alg  string   `json:"alg"`
+	Kid  string   `json:"kid,omitempty"`
+	Typ  string   `json:"typ,omitempty"`
+	Crit []string `json:"crit,omitempty"`
+}
+
+// Marshal signs the JSON encoding of v, which must be an object; raw JSON
+// may be passed as Bytes.
+func (j JWT) Marshal(v interface{}) ([]byte, error) {
+	if j.signingKey() == nil {
+		return nil, ErrSignNoPrivateKey
+	}
+	algorithm, ok := jwtAlgorithms[j.signingAlg]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrJWTUnsupportedAlgorithm, j.signingAlg)
+	}
+	claims, err := JSON{}.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(claims, []byte("{")) {
+		return nil, ErrJWTWrongValueType
+	}
+	header, err := json.Marshal(jwtHeader{Alg: j.signingAlg, Kid: j.signingKID, Typ: "JWT"})
+	if err != nil {
+		return nil, err
+	}
+
+	encoding := base64.RawURLEncoding
+	token := encoding.AppendEncode(nil, header)
+	token = append(token, '.')
+	token = encoding.AppendEncode(token, claims)
+	signature, err := algorithm.sign(j.signingKey(), token)
+	if err != nil {
+		return nil, err
+	}
+	token = append(token, '.')
+	return encoding.AppendEncode(token, signature), nil
+}
+
+// Unmarshal fails with ErrAuthentication if the token was not signed by a
+// key of the key set, or was altered since, and with ErrJWTExpired,
+// ErrJWTNotYetValid, ErrJWTInvalidIssuer or ErrJWTInvalidAudience if its
+// claims do not hold.
+func (j JWT) Unmarshal(data []byte, v interface{}) error {
+	parts := bytes.Split(data, []byte("."))
+	if len(parts) != 3 {
+		return ErrJWTMalformed
+	}
+	encoding := base64.RawURLEncoding.Strict()
+	rawHeader, err := encoding.AppendDecode(nil, parts[0])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrJWTMalformed, err)
+	}
+	var header jwtHeader
+	if err := json.Unmarshal(rawHeader, &header); err != nil {
+		return fmt.Errorf("%w: %w", ErrJWTMalformed, err)
+	}
+	if len(header.Crit) > 0 {
+		return fmt.Errorf("%w: critical header %q", ErrJWTMalformed, header.Crit)
+	}
+	algorithm, ok := jwtAlgorithms[header.Alg]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrJWTUnsupportedAlgorithm, header.Alg)
+	}
+	claims, err := encoding.AppendDecode(nil, parts[1])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrJWTMalformed, err)
+	}
+	signature, err := encoding.AppendDecode(nil, parts[2])
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrJWTMalformed, err)
+	}
+
+	key, err := j.keySet().VerificationKey(header.Kid, header.Alg)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAuthentication, err)
+	}
+	ok, err = algorithm.verify(key, data[:len(parts[0])+1+len(parts[1])], signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAuthentication, err)
+	}
+	if !ok {
+		return ErrAuthentication
+	}
+
+	if err := j.validate(claims); err != nil {
+		return err
+	}
+	return JSON{options: options{disallowUnknownFields: j.disallowUnknownFields}}.Unmarshal(claims, v)
+}
+
+func (j JWT) Reverse() Encoding {
+	return j
+}
+
+// keySet returns the key set, or the verifying half of the signing key.
+func (j JWT) keySet() JWTKeySet {
+	if j.keys != nil {
+		return j.keys.set
+	}
+	switch key := j.signingKey().(type) {
+	case []byte:
+		return PublicKeys{j.signingKID: key}
+	case crypto.Signer:
+		return PublicKeys{j.signingKID: key.Public()}
+	default:
+		return PublicKeys{}
+	}
+}
+
+// jwtClaims holds the registered claims Unmarshal checks.
+type jwtClaims struct {
+	Issuer    *string         `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *float64        `json:"exp"`
+	NotBefore *float64        `json:"nbf"`
+}
+
+func (j JWT) validate(data []byte) error {
+	var claims jwtClaims
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return fmt.Errorf("%w: %w", ErrJWTMalformed, err)
+	}
+
+	now, leeway := jwtNumericDate(time.Now()), j.leeway.Seconds()
+	if claims.ExpiresAt != nil && now >= *claims.ExpiresAt+leeway {
+		return ErrJWTExpired
+	}
+	if claims.NotBefore != nil && now < *claims.NotBefore-leeway {
+		return ErrJWTNotYetValid
+	}
+	if j.issuer != "" && (claims.Issuer == nil || *claims.Issuer != j.issuer) {
+		return ErrJWTInvalidIssuer
+	}
+	if j.audience != "" {
+		// aud is either one string or an array of them
+		var audience []string
+		var single string
+		if err := json.Unmarshal(claims.Audience, &single); err == nil {
+			audience = []string{single}
+		} else if err := json.Unmarshal(claims.Audience, &audience); err != nil {
+			return ErrJWTInvalidAudience
+		}
+		if !slices.Contains(audience, j.audience) {
+			return ErrJWTInvalidAudience
+		}
+	}
+	return nil
+}
+
+// jwtNumericDate converts t to a NumericDate, seconds since the epoch.
+// Claims are compared as NumericDates rather than converted to times, so
+// that dates beyond the range of time.Time keep their order: an nbf in the
+// far future is never reached, and an exp there never passes.
+func jwtNumericDate(t time.Time) float64 {
+	return float64(t.Unix()) + float64(t.Nanosecond())/float64(time.Second)
+}
+
+// jwtAlgorithm signs and verifies the signing input of a token. Both fail
+// with ErrSignInvalidKey if the key does not suit the algorithm, which
+// keeps a token from picking another algorithm for a known key.
+type jwtAlgorithm struct {
+	sign   func(key crypto.PrivateKey, input []byte) ([]byte, error)
+	verify func(key crypto.PublicKey, input, signature []byte) (bool, error)
+}
+
+var jwtAlgorithms = map[string]jwtAlgorithm{
+	JWTAlgHS256: {
+		sign: func(key crypto.PrivateKey, input []byte) ([]byte, error) {
+			secret, ok := key.([]byte)
+			if !ok {
+				return nil, ErrSignInvalidKey
+			}
+			mac := hmac.New(sha256.New, secret)
+			mac.Write(input)
+			return mac.Sum(nil), nil
+		},
+		verify: func(key crypto.PublicKey, input, signature []byte) (bool, error) {
+			secret, ok := key.([]byte)
+			if !ok {
+				return false, ErrSignInvalidKey
+			}
+			mac := hmac.New(sha256.New, secret)
+			mac.Write(input)
+			return hmac.Equal(mac.Sum(nil), signature), nil
+		},
+	},
+	JWTAlgRS256: {
+		sign: func(key crypto.PrivateKey, input []byte) ([]byte, error) {
+			private, ok := key.(*rsa.PrivateKey)
+			if !ok {
+				return nil, ErrSignInvalidKey
+			}
+			digest := sha256.Sum256(input)
+			return rsa.SignPKCS1v15(nil, private, crypto.SHA256, digest[:])
+		},
+		verify: func(key crypto.PublicKey, input, signature []byte) (bool, error) {
+			public, ok := key.(*rsa.PublicKey)
+			if !ok {
+				return false, ErrSignInvalidKey
+			}
+			digest := sha256.Sum256(input)
+			return rsa.VerifyPKCS1v15(public, crypto.SHA256, digest[:], signature) == nil, nil
+		},
+	},
+	JWTAlgES256: {
+		sign: func(key crypto.PrivateKey, input []byte) ([]byte, error) {
+			private, ok := key.(*ecdsa.PrivateKey)
+			if !ok || private.Curve != elliptic.P256() {
+				return nil, ErrSignInvalidKey
+			}
+			return signP256(private, input)
+		},
+		verify: func(key crypto.PublicKey, input, signature []byte) (bool, error) {
+			public, ok := key.(*ecdsa.PublicKey)
+			if !ok || public.Curve != elliptic.P256() {
+				return false, ErrSignInvalidKey
+			}
+			return verifyP256(public, input, signature), nil
+		},
+	},
+	JWTAlgEdDSA: {
+		sign: func(key crypto.PrivateKey, input []byte) ([]byte, error) {
+			private, ok := key.(ed25519.PrivateKey)
+			if !ok || len(private) != ed25519.PrivateKeySize {
+				return nil, ErrSignInvalidKey
+			}
+			return ed25519.Sign(private, input), nil
+		},
+		verify: func(key crypto.PublicKey, input, signature []byte) (bool, error) {
+			public, ok := key.(ed25519.PublicKey)
+			if !ok || len(public) != ed25519.PublicKeySize {
+				return false, ErrSignInvalidKey
+			}
+			return ed25519.Verify(public, input, signature), nil
+		},
+	},
+}
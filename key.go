@@ -0,0 +1,134 @@
+package encodingx
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrKeyNotFound    = errors.New("encoding key not found")
+	ErrKeyIDTooLong   = errors.New("encoding key ID longer than 255 bytes")
+	ErrAuthentication = errors.New("encoding message authentication failed")
+)
+
+// KeyProvider supplies the keys of the keyed encodings, AESGCM,
+// ChaCha20Poly1305 and HMAC. Data is sealed with the current key and carries
+// its ID, so that it still opens with Key once the current key has been
+// rotated.
+type KeyProvider interface {
+	// CurrentKey returns the ID and the key to seal new data with.
+	CurrentKey() (id string, key []byte, err error)
+	// Key returns the key sealed data names by id.
+	Key(id string) ([]byte, error)
+}
+
+// StaticKey returns a KeyProvider holding key alone, under the empty ID.
+func StaticKey(key []byte) KeyProvider {
+	return &staticKey{key: key}
+}
+
+// staticKey is used by pointer, so that encodings holding it stay
+// comparable.
+type staticKey struct {
+	key []byte
+}
+
+func (k *staticKey) CurrentKey() (string, []byte, error) {
+	return "", k.key, nil
+}
+
+func (k *staticKey) Key(id string) ([]byte, error) {
+	if id != "" {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, id)
+	}
+	return k.key, nil
+}
+
+// KeyRing is a KeyProvider for key rotation: Rotate makes a new key current,
+// while data sealed with the keys before it keeps opening until they are
+// removed. It is safe for concurrent use.
+type KeyRing struct {
+	mu      sync.RWMutex
+	current string
+	keys    map[string][]byte
+}
+
+// NewKeyRing creates a KeyRing whose current key is key, under id.
+func NewKeyRing(id string, key []byte) (*KeyRing, error) {
+	r := &KeyRing{keys: make(map[string][]byte)}
+	if err := r.Rotate(id, key); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Add adds key under id without making it current, replacing any key held
+// under id.
+func (r *KeyRing) Add(id string, key []byte) error {
+	if len(id) > 255 {
+		return ErrKeyIDTooLong
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[id] = key
+	return nil
+}
+
+// Rotate adds key under id and makes it the current key.
+func (r *KeyRing) Rotate(id string, key []byte) error {
+	if len(id) > 255 {
+		return ErrKeyIDTooLong
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys[id] = key
+	r.current = id
+	return nil
+}
+
+// Remove drops the key held under id, so that data sealed with it no longer
+// opens. Removing the current key leaves r without one until Rotate.
+func (r *KeyRing) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.keys, id)
+}
+
+func (r *KeyRing) CurrentKey() (string, []byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, ok := r.keys[r.current]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: no current key", ErrKeyNotFound)
+	}
+	return r.current, key, nil
+}
+
+func (r *KeyRing) Key(id string) ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, id)
+	}
+	return key, nil
+}
+
+// appendKeyID appends the key ID header: [1 byte length] + [key ID].
+func appendKeyID(dst []byte, id string) ([]byte, error) {
+	if len(id) > 255 {
+		return nil, ErrKeyIDTooLong
+	}
+	dst = append(dst, byte(len(id)))
+	return append(dst, id...), nil
+}
+
+// readKeyID splits the key ID header written by appendKeyID off data.
+func readKeyID(data []byte) (id string, header, rest []byte, ok bool) {
+	if len(data) < 1 || len(data) < 1+int(data[0]) {
+		return "", nil, nil, false
+	}
+	n := 1 + int(data[0])
+	return string(data[1:n]), data[:n], data[n:], true
+}
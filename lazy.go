@@ -2,6 +2,7 @@ package encodingx
 
 import (
 	"errors"
+	"io"
 
 	"github.com/aura-studio/reflectx"
 )
@@ -28,23 +29,20 @@ func (Lazy) Style() EncodingStyleType {
 	return EncodingStyleBytes
 }
 
-func (Lazy) Marshal(v interface{}) ([]byte, error) {
-	switch v := v.(type) {
-	case []byte:
-		data := make([]byte, len(v))
-		copy(data, v)
-		return data, nil
-	case Bytes:
-		data := make([]byte, len(v.Data))
-		copy(data, v.Data)
-		return data, nil
-	case *Bytes:
-		data := make([]byte, len(v.Data))
-		copy(data, v.Data)
-		return data, nil
-	default:
+func (l Lazy) Marshal(v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
 		return nil, ErrLazyWrongValueType
 	}
+	return l.AppendMarshal(make([]byte, 0, len(data)), data)
+}
+
+func (Lazy) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrLazyWrongValueType
+	}
+	return append(dst, data...), nil
 }
 
 func (Lazy) Unmarshal(data []byte, v interface{}) error {
@@ -58,6 +56,14 @@ func (Lazy) Unmarshal(data []byte, v interface{}) error {
 	}
 }
 
+func (Lazy) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(nopWriteCloser{w}, ErrLazyWrongValueType)
+}
+
+func (Lazy) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(r, ErrLazyWrongValueType)
+}
+
 func (l Lazy) Reverse() Encoding {
 	return l
 }
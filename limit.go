@@ -0,0 +1,135 @@
+package encodingx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrDecompressedTooLarge is returned once decompressed data breaks the
+// decompression limits, WithMaxDecompressedSize and WithMaxRatio, or the
+// byte budget of a chain.
+var (
+	ErrDecompressedTooLarge = errors.New("encoding decompressed data too large")
+)
+
+// DefaultMaxDecompressedSize is how much a decompressing encoding yields at
+// most without WithMaxDecompressedSize. HexZlib had no bound before it, so
+// larger HexZlib data now fails with ErrDecompressedTooLarge.
+const DefaultMaxDecompressedSize = 64 << 20
+
+// decompressLimits bounds what one decompression may yield, set through
+// WithMaxDecompressedSize and WithMaxRatio. Zero fields mean no bound.
+type decompressLimits struct {
+	maxSize  int64
+	maxRatio int64
+}
+
+func (o options) decompressLimits() decompressLimits {
+	limits := decompressLimits{
+		maxSize:  DefaultMaxDecompressedSize,
+		maxRatio: int64(max(o.maxRatio, 0)),
+	}
+	if o.hasMaxDecompressedSize {
+		limits.maxSize = max(o.maxDecompressedSize, 0)
+	}
+	return limits
+}
+
+// check fails when size decompressed bytes out of input compressed ones
+// break the limits.
+func (l decompressLimits) check(size, input int64) error {
+	if l.maxSize > 0 && size > l.maxSize {
+		return fmt.Errorf("%w: more than %d bytes", ErrDecompressedTooLarge, l.maxSize)
+	}
+	if l.maxRatio > 0 && size > l.maxRatio*input {
+		return fmt.Errorf("%w: more than %d times the %d compressed bytes", ErrDecompressedTooLarge, l.maxRatio, input)
+	}
+	return nil
+}
+
+// byteBudget is what one ChainEncoding Unmarshal or Decoder may pass
+// between its stages in total.
+type byteBudget struct {
+	limit int64
+	spent int64
+}
+
+func (b *byteBudget) spend(n int64) error {
+	b.spent += n
+	if b.spent > b.limit {
+		return fmt.Errorf("%w: chain byte budget of %d bytes spent", ErrDecompressedTooLarge, b.limit)
+	}
+	return nil
+}
+
+// spendOutput charges the n bytes a stage yielded, unless the stage spent
+// from the budget itself while it ran, as decompressors do.
+func (b *byteBudget) spendOutput(spentBefore int64, n int) error {
+	if b.spent != spentBefore {
+		return nil
+	}
+	return b.spend(int64(n))
+}
+
+type byteBudgetKey struct{}
+
+// withByteBudget hands b to the decompressors ctx is passed to.
+func withByteBudget(ctx context.Context, b *byteBudget) context.Context {
+	return context.WithValue(ctx, byteBudgetKey{}, b)
+}
+
+func byteBudgetFrom(ctx context.Context) *byteBudget {
+	b, _ := ctx.Value(byteBudgetKey{}).(*byteBudget)
+	return b
+}
+
+// limitReader fails with ErrDecompressedTooLarge once what was read
+// through it breaks limits or spends more than budget holds. input reports
+// how many compressed bytes the data came from so far.
+type limitReader struct {
+	r      io.Reader
+	limits decompressLimits
+	input  func() int64
+	budget *byteBudget
+	n      int64
+}
+
+func newLimitReader(r io.Reader, limits decompressLimits, input func() int64, budget *byteBudget) io.Reader {
+	if limits == (decompressLimits{}) && budget == nil {
+		return r
+	}
+	return &limitReader{r: r, limits: limits, input: input, budget: budget}
+}
+
+func (lr *limitReader) Read(p []byte) (int, error) {
+	// Read one byte past what is left, which is enough to tell that the
+	// data goes on, instead of inflating a whole buffer beyond the limit
+	left := int64(-1)
+	if lr.limits.maxSize > 0 {
+		left = lr.limits.maxSize - lr.n
+	}
+	if lr.budget != nil && (left < 0 || lr.budget.limit-lr.budget.spent < left) {
+		left = max(lr.budget.limit-lr.budget.spent, 0)
+	}
+	if left >= 0 && int64(len(p)) > left+1 {
+		p = p[:left+1]
+	}
+
+	n, err := lr.r.Read(p)
+	lr.n += int64(n)
+	var input int64
+	if lr.input != nil {
+		input = lr.input()
+	}
+	if limitErr := lr.limits.check(lr.n, input); limitErr != nil {
+		return 0, limitErr
+	}
+	if lr.budget != nil {
+		if budgetErr := lr.budget.spend(int64(n)); budgetErr != nil {
+			return 0, budgetErr
+		}
+	}
+	return n, err
+}
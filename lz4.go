@@ -0,0 +1,245 @@
+package encodingx
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/aura-studio/reflectx"
+	"github.com/pierrec/lz4/v4"
+)
+
+var (
+	ErrLZ4WrongValueType      = errors.New("encoding LZ4 converts on wrong type value")
+	ErrLZ4BlockWrongValueType = errors.New("encoding LZ4Block converts on wrong type value")
+	ErrLZ4BlockInvalidData    = errors.New("encoding LZ4Block invalid data")
+)
+
+func init() {
+	register(NewLZ4())
+	register(NewLZ4Block())
+}
+
+// ============================================================================
+// LZ4 - LZ4 frame format, as written by the lz4 command line tool
+// WithLevel takes 0 for the fast compressor or 1 to 9 for the high
+// compression one.
+// ============================================================================
+
+type LZ4 struct {
+	options
+}
+
+// NewLZ4 creates an LZ4 encoding, honoring WithName, WithLevel and the
+// decompression limits.
+func NewLZ4(opts ...CompressOption) *LZ4 {
+	return &LZ4{
+		options: newOptions(opts, CompressOption.applyCompress),
+	}
+}
+
+func (l LZ4) String() string {
+	return l.nameOr(reflectx.TypeName(l))
+}
+
+func (LZ4) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (l LZ4) Marshal(v interface{}) ([]byte, error) {
+	return l.AppendMarshal(nil, v)
+}
+
+func (l LZ4) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	return l.appendMarshal(context.Background(), dst, v)
+}
+
+// MarshalContext is like Marshal but stops compressing once ctx is done.
+func (l LZ4) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	return l.appendMarshal(ctx, nil, v)
+}
+
+func (l LZ4) appendMarshal(ctx context.Context, dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrLZ4WrongValueType
+	}
+	return lz4Format.appendCompress(ctx, dst, data, l.levelOr(0), nil)
+}
+
+func (l LZ4) Unmarshal(data []byte, v interface{}) error {
+	return l.UnmarshalContext(context.Background(), data, v)
+}
+
+// UnmarshalContext is like Unmarshal but stops decompressing once ctx is
+// done.
+func (l LZ4) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decompressed, err := lz4Format.decompress(ctx, data, nil, l.decompressLimits())
+		if err != nil {
+			return err
+		}
+		v.Data = decompressed
+		return nil
+	default:
+		return ErrLZ4WrongValueType
+	}
+}
+
+func (l LZ4) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(lz4Format.newStreamWriter(w, l.levelOr(0), nil), ErrLZ4WrongValueType)
+}
+
+func (l LZ4) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(lz4Format.newStreamReader(r, nil, l.decompressLimits()), ErrLZ4WrongValueType)
+}
+
+func (l LZ4) Reverse() Encoding {
+	return l
+}
+
+var lz4Format = &compressFormat{
+	newWriter: func(w io.Writer, level int, _ []byte) (compressWriter, error) {
+		compressionLevel, err := lz4Level(level)
+		if err != nil {
+			return nil, err
+		}
+		zw := lz4.NewWriter(w)
+		if err := zw.Apply(lz4.CompressionLevelOption(compressionLevel), lz4.ConcurrencyOption(1)); err != nil {
+			return nil, err
+		}
+		return zw, nil
+	},
+	newReader: func(r io.Reader, _ []byte) (io.ReadCloser, error) {
+		return io.NopCloser(lz4.NewReader(r)), nil
+	},
+}
+
+// lz4Level maps level 0 to lz4.Fast and 1 to 9 to lz4.Level1 to lz4.Level9.
+func lz4Level(level int) (lz4.CompressionLevel, error) {
+	switch {
+	case level == 0:
+		return lz4.Fast, nil
+	case level >= 1 && level <= 9:
+		return lz4.Level1 << (level - 1), nil
+	default:
+		return 0, lz4.ErrOptionInvalidCompressionLevel
+	}
+}
+
+// ============================================================================
+// LZ4Block - a single LZ4 block, for small messages
+// Format: [uvarint decompressed length] + [LZ4 block]
+// The block format has no checksum and no streaming; WithLevel is read as
+// for LZ4.
+// ============================================================================
+
+// lz4MaxRatio bounds how much one byte of an LZ4 block can expand to, so
+// that a forged length is rejected before it is allocated.
+const lz4MaxRatio = 255
+
+// Block compressors keep their hash tables between blocks.
+var (
+	lz4CompressorPool = sync.Pool{
+		New: func() interface{} {
+			return new(lz4.Compressor)
+		},
+	}
+	lz4CompressorHCPool = sync.Pool{
+		New: func() interface{} {
+			return new(lz4.CompressorHC)
+		},
+	}
+)
+
+type LZ4Block struct {
+	options
+}
+
+// NewLZ4Block creates an LZ4Block encoding, honoring WithName, WithLevel
+// and the decompression limits.
+func NewLZ4Block(opts ...CompressOption) *LZ4Block {
+	return &LZ4Block{
+		options: newOptions(opts, CompressOption.applyCompress),
+	}
+}
+
+func (l LZ4Block) String() string {
+	return l.nameOr(reflectx.TypeName(l))
+}
+
+func (LZ4Block) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (l LZ4Block) Marshal(v interface{}) ([]byte, error) {
+	return l.AppendMarshal(nil, v)
+}
+
+func (l LZ4Block) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrLZ4BlockWrongValueType
+	}
+	level, err := lz4Level(l.levelOr(0))
+	if err != nil {
+		return nil, err
+	}
+	dst = binary.AppendUvarint(dst, uint64(len(data)))
+	if len(data) == 0 {
+		return dst, nil
+	}
+
+	start := len(dst)
+	dst = append(dst, make([]byte, lz4.CompressBlockBound(len(data)))...)
+	var n int
+	if level == lz4.Fast {
+		c := lz4CompressorPool.Get().(*lz4.Compressor)
+		n, err = c.CompressBlock(data, dst[start:])
+		lz4CompressorPool.Put(c)
+	} else {
+		c := lz4CompressorHCPool.Get().(*lz4.CompressorHC)
+		c.Level = level
+		n, err = c.CompressBlock(data, dst[start:])
+		lz4CompressorHCPool.Put(c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dst[:start+n], nil
+}
+
+func (l LZ4Block) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		size, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrLZ4BlockInvalidData
+		}
+		block := data[n:]
+		if size > uint64(len(block))*lz4MaxRatio || (size == 0) != (len(block) == 0) {
+			return ErrLZ4BlockInvalidData
+		}
+		if err := l.decompressLimits().check(int64(size), int64(len(block))); err != nil {
+			return err
+		}
+		decompressed := make([]byte, size)
+		if size > 0 {
+			n, err := lz4.UncompressBlock(block, decompressed)
+			if err != nil || n != len(decompressed) {
+				return ErrLZ4BlockInvalidData
+			}
+		}
+		v.Data = decompressed
+		return nil
+	default:
+		return ErrLZ4BlockWrongValueType
+	}
+}
+
+func (l LZ4Block) Reverse() Encoding {
+	return l
+}
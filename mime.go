@@ -0,0 +1,302 @@
+package encodingx
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrQuotedPrintableWrongValueType = errors.New("encoding quoted-printable converts on wrong type value")
+	ErrEncodedWordWrongValueType     = errors.New("encoding encoded-word converts on wrong type value")
+	ErrEncodedWordInvalidData        = errors.New("encoding encoded-word invalid data")
+	ErrEncodedWordCharsetMismatch    = errors.New("encoding encoded-word charset mismatch")
+)
+
+func init() {
+	register(NewQuotedPrintable())
+	register(NewEncodedWord())
+	register(NewEncodedWord(WithName("EncodedWordQ"), WithQEncoding()))
+}
+
+// ============================================================================
+// QuotedPrintable - RFC 2045 quoted-printable, lines wrapped at 76 columns
+// Line breaks in the data are encoded as =0D=0A so that every byte
+// survives a round trip. With WithTextMode they are written as hard line
+// breaks instead, as for text bodies, and come back as CRLF.
+// ============================================================================
+
+type QuotedPrintable struct {
+	options
+}
+
+// NewQuotedPrintable creates a QuotedPrintable encoding, honoring WithName
+// and WithTextMode.
+func NewQuotedPrintable(opts ...QuotedPrintableOption) *QuotedPrintable {
+	return &QuotedPrintable{
+		options: newOptions(opts, QuotedPrintableOption.applyQuotedPrintable),
+	}
+}
+
+func (q QuotedPrintable) String() string {
+	return q.nameOr(reflectx.TypeName(q))
+}
+
+func (QuotedPrintable) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (q QuotedPrintable) Marshal(v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrQuotedPrintableWrongValueType
+	}
+	var buf bytes.Buffer
+	w := q.newWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (QuotedPrintable) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(data)))
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrQuotedPrintableWrongValueType
+	}
+}
+
+func (q QuotedPrintable) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(q.newWriter(w), ErrQuotedPrintableWrongValueType)
+}
+
+func (QuotedPrintable) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(quotedprintable.NewReader(r), ErrQuotedPrintableWrongValueType)
+}
+
+func (q QuotedPrintable) Reverse() Encoding {
+	return q
+}
+
+func (q QuotedPrintable) newWriter(w io.Writer) *quotedprintable.Writer {
+	qw := quotedprintable.NewWriter(w)
+	qw.Binary = !q.textMode
+	return qw
+}
+
+// ============================================================================
+// EncodedWord - RFC 2047 encoded-words for MIME header values
+// Format: "=?" + charset + "?" + B or Q + "?" + encoded text + "?=", split
+// into words of at most 75 characters separated by a space. The data is
+// taken as text in the charset, utf-8 by default, and is never transcoded;
+// only UTF-8 characters are kept whole across words. Unmarshal accepts
+// both forms, keeps text outside encoded-words and drops the whitespace
+// between adjacent encoded-words.
+// ============================================================================
+
+const maxEncodedWordLen = 75
+
+type EncodedWord struct {
+	options
+}
+
+// NewEncodedWord creates an EncodedWord encoding, honoring WithName,
+// WithQEncoding and WithCharset.
+func NewEncodedWord(opts ...EncodedWordOption) *EncodedWord {
+	return &EncodedWord{
+		options: newOptions(opts, EncodedWordOption.applyEncodedWord),
+	}
+}
+
+func (e EncodedWord) String() string {
+	return e.nameOr(reflectx.TypeName(e))
+}
+
+func (EncodedWord) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (e EncodedWord) Marshal(v interface{}) ([]byte, error) {
+	return e.AppendMarshal(nil, v)
+}
+
+func (e EncodedWord) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrEncodedWordWrongValueType
+	}
+	charset := e.charsetOr("utf-8")
+	form := byte('B')
+	if e.qEncoding {
+		form = 'Q'
+	}
+	room := maxEncodedWordLen - len("=??X??=") - len(charset)
+	if room < 4 {
+		return nil, ErrEncodedWordInvalidData
+	}
+
+	for i := 0; len(data) > 0; i++ {
+		n := encodedWordChunk(data, room, form, charset)
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, "=?"...)
+		dst = append(dst, charset...)
+		dst = append(dst, '?', form, '?')
+		if form == 'B' {
+			dst = base64.StdEncoding.AppendEncode(dst, data[:n])
+		} else {
+			dst = appendQEncoding(dst, data[:n])
+		}
+		dst = append(dst, "?="...)
+		data = data[n:]
+	}
+	return dst, nil
+}
+
+func (e EncodedWord) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded, err := decodeEncodedWords(data, e.charsetOr("utf-8"))
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrEncodedWordWrongValueType
+	}
+}
+
+func (e EncodedWord) Reverse() Encoding {
+	return e
+}
+
+// encodedWordChunk returns how many leading bytes of data fit in one
+// encoded-word with room characters for the encoded text.
+func encodedWordChunk(data []byte, room int, form byte, charset string) int {
+	n := 0
+	if form == 'B' {
+		n = min(len(data), room/4*3)
+	} else {
+		for width := 0; n < len(data); n++ {
+			w := 1
+			if !isQEncodingSafe(data[n]) {
+				w = 3
+			}
+			if width+w > room {
+				break
+			}
+			width += w
+		}
+	}
+	if n < len(data) && strings.EqualFold(charset, "utf-8") {
+		// Back off to a character boundary, unless one character alone
+		// does not fit
+		for end := n; end > 0; end-- {
+			if utf8.RuneStart(data[end]) {
+				n = end
+				break
+			}
+		}
+	}
+	return n
+}
+
+func isQEncodingSafe(c byte) bool {
+	return c > ' ' && c <= '~' && c != '=' && c != '?' && c != '_'
+}
+
+func appendQEncoding(dst, data []byte) []byte {
+	const upperhex = "0123456789ABCDEF"
+	for _, c := range data {
+		switch {
+		case c == ' ':
+			dst = append(dst, '_')
+		case isQEncodingSafe(c):
+			dst = append(dst, c)
+		default:
+			dst = append(dst, '=', upperhex[c>>4], upperhex[c&15])
+		}
+	}
+	return dst
+}
+
+func decodeEncodedWords(data []byte, charset string) ([]byte, error) {
+	var decoded []byte
+	afterWord := false
+	for len(data) > 0 {
+		start := bytes.Index(data, []byte("=?"))
+		if start < 0 {
+			return append(decoded, data...), nil
+		}
+		if literal := data[:start]; !afterWord || len(bytes.TrimSpace(literal)) > 0 {
+			decoded = append(decoded, literal...)
+		}
+
+		// charset ? form ? text ?=
+		fields := bytes.SplitN(data[start+2:], []byte("?"), 3)
+		if len(fields) < 3 || len(fields[1]) != 1 {
+			return nil, ErrEncodedWordInvalidData
+		}
+		text, rest, ok := bytes.Cut(fields[2], []byte("?="))
+		if !ok {
+			return nil, ErrEncodedWordInvalidData
+		}
+		wordCharset, _, _ := strings.Cut(string(fields[0]), "*")
+		if !strings.EqualFold(wordCharset, charset) && !strings.EqualFold(wordCharset, "us-ascii") {
+			return nil, ErrEncodedWordCharsetMismatch
+		}
+
+		switch fields[1][0] {
+		case 'B', 'b':
+			buf := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+			n, err := base64.StdEncoding.Decode(buf, text)
+			if err != nil {
+				return nil, ErrEncodedWordInvalidData
+			}
+			decoded = append(decoded, buf[:n]...)
+		case 'Q', 'q':
+			for i := 0; i < len(text); i++ {
+				switch c := text[i]; c {
+				case '_':
+					decoded = append(decoded, ' ')
+				case '=':
+					if i+2 >= len(text) {
+						return nil, ErrEncodedWordInvalidData
+					}
+					hi, ok1 := unhex(text[i+1])
+					lo, ok2 := unhex(text[i+2])
+					if !ok1 || !ok2 {
+						return nil, ErrEncodedWordInvalidData
+					}
+					decoded = append(decoded, hi<<4|lo)
+					i += 2
+				default:
+					decoded = append(decoded, c)
+				}
+			}
+		default:
+			return nil, ErrEncodedWordInvalidData
+		}
+		data = rest
+		afterWord = true
+	}
+	return decoded, nil
+}
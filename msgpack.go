@@ -1,6 +1,8 @@
 package encodingx
 
 import (
+	"io"
+
 	"github.com/aura-studio/reflectx"
 	"github.com/vmihailenco/msgpack/v5"
 )
@@ -39,6 +41,18 @@ func (MsgPack) Unmarshal(data []byte, v interface{}) error {
 	return msgpack.Unmarshal(data, v)
 }
 
+// NewEncoder returns an Encoder writing MsgPack values to w.
+func (MsgPack) NewEncoder(w io.Writer) Encoder {
+	return streamEncoder{
+		encode: msgpack.NewEncoder(w).Encode,
+	}
+}
+
+// NewDecoder returns a Decoder reading successive MsgPack values from r.
+func (MsgPack) NewDecoder(r io.Reader) Decoder {
+	return msgpack.NewDecoder(r)
+}
+
 // Reverse returns the encoder itself since MsgPack is symmetric
 // (the same encoder is used for both serialization and deserialization).
 func (m MsgPack) Reverse() Encoding {
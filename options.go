@@ -0,0 +1,593 @@
+package encodingx
+
+import (
+	"crypto"
+	"fmt"
+	"time"
+)
+
+// Option configures any encoding at construction, as in
+// NewJSON(WithName("acme.JSON")). Without options an encoding behaves
+// exactly like its zero value. Beside Option, which only WithName returns,
+// each family of encodings takes an option type of its own, such as
+// JSONOption or CompressOption, so that an option an encoding has no use
+// for fails to compile instead of being ignored.
+type Option interface {
+	JSONOption
+	XMLOption
+	YAMLOption
+	CSVOption
+	Base32Option
+	Base58Option
+	Base85Option
+	Base64Option
+	PercentOption
+	QuotedPrintableOption
+	EncodedWordOption
+	DecompressOption
+	CompressOption
+	DictionaryCompressOption
+	HexTierOption
+	HexZlibOption
+	HexTierSealedOption
+	AEADOption
+	HMACOption
+	JWTOption
+	apply(*options)
+}
+
+// JSONOption configures NewJSON.
+type JSONOption interface{ applyJSON(*options) }
+
+// XMLOption configures NewXML.
+type XMLOption interface{ applyXML(*options) }
+
+// YAMLOption configures NewYAML.
+type YAMLOption interface{ applyYAML(*options) }
+
+// CSVOption configures NewCSV and NewCSVWithHeaders.
+type CSVOption interface{ applyCSV(*options) }
+
+// Base32Option configures NewBase32Crockford.
+type Base32Option interface{ applyBase32(*options) }
+
+// Base58Option configures NewBase58Check.
+type Base58Option interface{ applyBase58(*options) }
+
+// Base85Option configures NewZ85.
+type Base85Option interface{ applyBase85(*options) }
+
+// Base64Option configures NewBase64, NewBase64URL, NewBase64Raw and
+// NewBase64URLRaw.
+type Base64Option interface{ applyBase64(*options) }
+
+// PercentOption configures NewPercentEncoding.
+type PercentOption interface{ applyPercent(*options) }
+
+// QuotedPrintableOption configures NewQuotedPrintable.
+type QuotedPrintableOption interface{ applyQuotedPrintable(*options) }
+
+// EncodedWordOption configures NewEncodedWord.
+type EncodedWordOption interface{ applyEncodedWord(*options) }
+
+// DecompressOption configures NewSnappy and NewSnappyFramed, which have no
+// compression levels.
+type DecompressOption interface{ applyDecompress(*options) }
+
+// CompressOption configures NewGzip, NewBrotli, NewLZ4 and NewLZ4Block.
+type CompressOption interface{ applyCompress(*options) }
+
+// DictionaryCompressOption configures NewDeflate, NewZlib and NewZstd,
+// which also take a dictionary.
+type DictionaryCompressOption interface{ applyDictionaryCompress(*options) }
+
+// HexTierOption configures NewHexTier and NewHexTierRand.
+type HexTierOption interface{ applyHexTier(*options) }
+
+// HexZlibOption configures NewHexZlib.
+type HexZlibOption interface{ applyHexZlib(*options) }
+
+// HexTierSealedOption configures NewHexTierSealed.
+type HexTierSealedOption interface{ applyHexTierSealed(*options) }
+
+// AEADOption configures NewAESGCM and NewChaCha20Poly1305.
+type AEADOption interface{ applyAEAD(*options) }
+
+// HMACOption configures NewHMAC.
+type HMACOption interface{ applyHMAC(*options) }
+
+// JWTOption configures NewJWT.
+type JWTOption interface{ applyJWT(*options) }
+
+// Options taken by several families.
+type (
+	// IndentOption configures JSON, XML and YAML.
+	IndentOption interface {
+		JSONOption
+		XMLOption
+		YAMLOption
+	}
+	// PrefixOption configures JSON and XML.
+	PrefixOption interface {
+		JSONOption
+		XMLOption
+	}
+	// UnknownFieldsOption configures JSON, YAML and JWT.
+	UnknownFieldsOption interface {
+		JSONOption
+		YAMLOption
+		JWTOption
+	}
+	// LevelOption configures the compressing encodings with levels.
+	LevelOption interface {
+		CompressOption
+		DictionaryCompressOption
+		HexZlibOption
+	}
+	// DictionaryOption configures the compressing encodings with
+	// dictionaries.
+	DictionaryOption interface {
+		DictionaryCompressOption
+		HexZlibOption
+	}
+	// LimitOption configures every decompressing encoding.
+	LimitOption interface {
+		DecompressOption
+		CompressOption
+		DictionaryCompressOption
+		HexZlibOption
+	}
+	// TierPolicyOption configures the tier encodings.
+	TierPolicyOption interface {
+		HexTierOption
+		HexZlibOption
+		HexTierSealedOption
+	}
+)
+
+// The option types below implement the apply method of each family they
+// configure.
+
+type nameOption func(*options)
+
+func (f nameOption) apply(o *options)                   { f(o) }
+func (f nameOption) applyJSON(o *options)               { f(o) }
+func (f nameOption) applyXML(o *options)                { f(o) }
+func (f nameOption) applyYAML(o *options)               { f(o) }
+func (f nameOption) applyCSV(o *options)                { f(o) }
+func (f nameOption) applyBase32(o *options)             { f(o) }
+func (f nameOption) applyBase58(o *options)             { f(o) }
+func (f nameOption) applyBase85(o *options)             { f(o) }
+func (f nameOption) applyBase64(o *options)             { f(o) }
+func (f nameOption) applyPercent(o *options)            { f(o) }
+func (f nameOption) applyQuotedPrintable(o *options)    { f(o) }
+func (f nameOption) applyEncodedWord(o *options)        { f(o) }
+func (f nameOption) applyDecompress(o *options)         { f(o) }
+func (f nameOption) applyCompress(o *options)           { f(o) }
+func (f nameOption) applyDictionaryCompress(o *options) { f(o) }
+func (f nameOption) applyHexTier(o *options)            { f(o) }
+func (f nameOption) applyHexZlib(o *options)            { f(o) }
+func (f nameOption) applyHexTierSealed(o *options)      { f(o) }
+func (f nameOption) applyAEAD(o *options)               { f(o) }
+func (f nameOption) applyHMAC(o *options)               { f(o) }
+func (f nameOption) applyJWT(o *options)                { f(o) }
+
+type indentOption func(*options)
+
+func (f indentOption) applyJSON(o *options) { f(o) }
+func (f indentOption) applyXML(o *options)  { f(o) }
+func (f indentOption) applyYAML(o *options) { f(o) }
+
+type prefixOption func(*options)
+
+func (f prefixOption) applyJSON(o *options) { f(o) }
+func (f prefixOption) applyXML(o *options)  { f(o) }
+
+type unknownFieldsOption func(*options)
+
+func (f unknownFieldsOption) applyJSON(o *options) { f(o) }
+func (f unknownFieldsOption) applyYAML(o *options) { f(o) }
+func (f unknownFieldsOption) applyJWT(o *options)  { f(o) }
+
+type levelOption func(*options)
+
+func (f levelOption) applyCompress(o *options)           { f(o) }
+func (f levelOption) applyDictionaryCompress(o *options) { f(o) }
+func (f levelOption) applyHexZlib(o *options)            { f(o) }
+
+type dictionaryOption func(*options)
+
+func (f dictionaryOption) applyDictionaryCompress(o *options) { f(o) }
+func (f dictionaryOption) applyHexZlib(o *options)            { f(o) }
+
+type limitOption func(*options)
+
+func (f limitOption) applyDecompress(o *options)         { f(o) }
+func (f limitOption) applyCompress(o *options)           { f(o) }
+func (f limitOption) applyDictionaryCompress(o *options) { f(o) }
+func (f limitOption) applyHexZlib(o *options)            { f(o) }
+
+type tierPolicyOption func(*options)
+
+func (f tierPolicyOption) applyHexTier(o *options)       { f(o) }
+func (f tierPolicyOption) applyHexZlib(o *options)       { f(o) }
+func (f tierPolicyOption) applyHexTierSealed(o *options) { f(o) }
+
+type csvOption func(*options)
+
+func (f csvOption) applyCSV(o *options) { f(o) }
+
+type base32Option func(*options)
+
+func (f base32Option) applyBase32(o *options) { f(o) }
+
+type base58Option func(*options)
+
+func (f base58Option) applyBase58(o *options) { f(o) }
+
+type base85Option func(*options)
+
+func (f base85Option) applyBase85(o *options) { f(o) }
+
+type base64Option func(*options)
+
+func (f base64Option) applyBase64(o *options) { f(o) }
+
+type percentOption func(*options)
+
+func (f percentOption) applyPercent(o *options) { f(o) }
+
+type quotedPrintableOption func(*options)
+
+func (f quotedPrintableOption) applyQuotedPrintable(o *options) { f(o) }
+
+type encodedWordOption func(*options)
+
+func (f encodedWordOption) applyEncodedWord(o *options) { f(o) }
+
+type aeadOption func(*options)
+
+func (f aeadOption) applyAEAD(o *options) { f(o) }
+
+type hmacOption func(*options)
+
+func (f hmacOption) applyHMAC(o *options) { f(o) }
+
+type jwtOption func(*options)
+
+func (f jwtOption) applyJWT(o *options) { f(o) }
+
+type hexTierSealedOption func(*options)
+
+func (f hexTierSealedOption) applyHexTierSealed(o *options) { f(o) }
+
+type options struct {
+	name                   string
+	prefix                 string
+	indent                 string
+	disallowUnknownFields  bool
+	delimiter              rune
+	checkSymbol            bool
+	version                byte
+	padding                bool
+	lenient                bool
+	percentMode            PercentMode
+	textMode               bool
+	qEncoding              bool
+	charset                string
+	level                  int
+	hasLevel               bool
+	maxDecompressedSize    int64
+	hasMaxDecompressedSize bool
+	maxRatio               int
+	hash                   crypto.Hash
+	signingAlg             string
+	signingKID             string
+	issuer                 string
+	audience               string
+	leeway                 time.Duration
+	legacyDecode           bool
+	// Settings of slice or interface type sit behind a pointer, which keeps
+	// the encodings embedding options comparable with ==.
+	refs *optionRefs
+}
+
+type optionRefs struct {
+	dictionary     []byte
+	associatedData []byte
+	signingKey     crypto.PrivateKey
+	tierPolicy     TierPolicy
+}
+
+// newOptions applies opts of one family through its apply method, as in
+// newOptions(opts, JSONOption.applyJSON).
+func newOptions[O any](opts []O, apply func(O, *options)) options {
+	var o options
+	for _, opt := range opts {
+		apply(opt, &o)
+	}
+	return o
+}
+
+// WithName sets the name the encoding reports from String, and so the name
+// it is registered under. A configured instance can then be registered
+// beside the default one and referenced from chains.
+func WithName(name string) Option {
+	return nameOption(func(o *options) {
+		o.name = name
+	})
+}
+
+// WithIndent indents nested elements with indent, for JSON, XML and YAML.
+// YAML only takes the width of indent into account.
+func WithIndent(indent string) IndentOption {
+	return indentOption(func(o *options) {
+		o.indent = indent
+	})
+}
+
+// WithPrefix starts every line but the first with prefix, for JSON and XML.
+func WithPrefix(prefix string) PrefixOption {
+	return prefixOption(func(o *options) {
+		o.prefix = prefix
+	})
+}
+
+// WithDisallowUnknownFields makes JSON and YAML fail to unmarshal input
+// holding fields the target does not have.
+func WithDisallowUnknownFields() UnknownFieldsOption {
+	return unknownFieldsOption(func(o *options) {
+		o.disallowUnknownFields = true
+	})
+}
+
+// WithDelimiter sets the field delimiter of CSV encodings, e.g. '\t' for TSV.
+func WithDelimiter(delimiter rune) CSVOption {
+	return csvOption(func(o *options) {
+		o.delimiter = delimiter
+	})
+}
+
+// WithCheckSymbol makes Base32Crockford append a check symbol on Marshal
+// and verify it on Unmarshal.
+func WithCheckSymbol() Base32Option {
+	return base32Option(func(o *options) {
+		o.checkSymbol = true
+	})
+}
+
+// WithVersion sets the version byte Base58Check prefixes the payload with,
+// 0x00 by default as for Bitcoin addresses.
+func WithVersion(version byte) Base58Option {
+	return base58Option(func(o *options) {
+		o.version = version
+	})
+}
+
+// WithPadding lets Z85 encode data of any length by padding it to a
+// multiple of 4 and recording the padding length.
+func WithPadding() Base85Option {
+	return base85Option(func(o *options) {
+		o.padding = true
+	})
+}
+
+// WithLenient makes base64 encodings accept padded and unpadded input and
+// skip whitespace, such as line breaks, on Unmarshal. Padding must still end
+// the input and match the length of the data, as in "QQ==" or "QQ".
+func WithLenient() Base64Option {
+	return base64Option(func(o *options) {
+		o.lenient = true
+	})
+}
+
+// WithPercentMode sets which characters PercentEncoding leaves unescaped,
+// PercentComponent by default. NewPercentEncoding panics with
+// ErrPercentEncodingInvalidMode when mode is not one of the PercentMode
+// constants.
+func WithPercentMode(mode PercentMode) PercentOption {
+	return percentOption(func(o *options) {
+		if !mode.valid() {
+			panic(fmt.Errorf("%w: %s", ErrPercentEncodingInvalidMode, mode))
+		}
+		o.percentMode = mode
+	})
+}
+
+// WithTextMode makes QuotedPrintable write line breaks of the data as hard
+// line breaks, which decode as CRLF, instead of encoding them.
+func WithTextMode() QuotedPrintableOption {
+	return quotedPrintableOption(func(o *options) {
+		o.textMode = true
+	})
+}
+
+// WithQEncoding makes EncodedWord use the Q form instead of the B form.
+func WithQEncoding() EncodedWordOption {
+	return encodedWordOption(func(o *options) {
+		o.qEncoding = true
+	})
+}
+
+// WithCharset sets the charset EncodedWord declares, utf-8 by default.
+func WithCharset(charset string) EncodedWordOption {
+	return encodedWordOption(func(o *options) {
+		o.charset = charset
+	})
+}
+
+// WithDictionary presets the compression dictionary of Deflate, Zlib,
+// HexZlib and Zstd, which takes a trained zstd dictionary. Data compressed
+// with a dictionary only decompresses with the same one.
+func WithDictionary(dictionary []byte) DictionaryOption {
+	return dictionaryOption(func(o *options) {
+		o.ref().dictionary = dictionary
+	})
+}
+
+// WithMaxDecompressedSize makes decompressing encodings fail with
+// ErrDecompressedTooLarge rather than yield more than size bytes, instead
+// of DefaultMaxDecompressedSize. A size of 0 or less removes the bound.
+func WithMaxDecompressedSize(size int64) LimitOption {
+	return limitOption(func(o *options) {
+		o.maxDecompressedSize = size
+		o.hasMaxDecompressedSize = true
+	})
+}
+
+// WithMaxRatio makes decompressing encodings fail with
+// ErrDecompressedTooLarge rather than yield more than ratio times the size
+// of their input. Streaming decoders compare against the input read so far.
+func WithMaxRatio(ratio int) LimitOption {
+	return limitOption(func(o *options) {
+		o.maxRatio = ratio
+	})
+}
+
+// WithAssociatedData makes AESGCM and ChaCha20Poly1305 authenticate
+// associatedData along with the data, without storing it. Unmarshal then
+// only succeeds with the same associated data.
+func WithAssociatedData(associatedData []byte) AEADOption {
+	return aeadOption(func(o *options) {
+		o.ref().associatedData = associatedData
+	})
+}
+
+// WithHash sets the hash function of HMAC: crypto.SHA256, the default,
+// crypto.SHA384 or crypto.SHA512.
+func WithHash(hash crypto.Hash) HMACOption {
+	return hmacOption(func(o *options) {
+		o.hash = hash
+	})
+}
+
+// WithSigningKey makes JWT sign with key under kid, using alg: a []byte
+// secret for JWTAlgHS256, *rsa.PrivateKey for JWTAlgRS256,
+// *ecdsa.PrivateKey on P-256 for JWTAlgES256 or ed25519.PrivateKey for
+// JWTAlgEdDSA.
+func WithSigningKey(alg, kid string, key crypto.PrivateKey) JWTOption {
+	return jwtOption(func(o *options) {
+		o.signingAlg = alg
+		o.signingKID = kid
+		o.ref().signingKey = key
+	})
+}
+
+// WithIssuer makes JWT reject tokens whose iss claim is not issuer.
+func WithIssuer(issuer string) JWTOption {
+	return jwtOption(func(o *options) {
+		o.issuer = issuer
+	})
+}
+
+// WithAudience makes JWT reject tokens whose aud claim does not name
+// audience.
+func WithAudience(audience string) JWTOption {
+	return jwtOption(func(o *options) {
+		o.audience = audience
+	})
+}
+
+// WithLeeway makes JWT accept tokens up to leeway past their exp claim or
+// ahead of their nbf claim, allowing for clock skew.
+func WithLeeway(leeway time.Duration) JWTOption {
+	return jwtOption(func(o *options) {
+		o.leeway = leeway
+	})
+}
+
+// WithLegacyDecode makes HexTierSealed also accept data written by
+// HexTierRand under the same tier policy, while tokens issued before a
+// migration are still around. Such data is not authenticated.
+func WithLegacyDecode() HexTierSealedOption {
+	return hexTierSealedOption(func(o *options) {
+		o.legacyDecode = true
+	})
+}
+
+// WithTierPolicy sets the sizes HexTier, HexTierRand, HexZlib and
+// HexTierSealed pad to and accept, powers of two by default.
+func WithTierPolicy(policy TierPolicy) TierPolicyOption {
+	return tierPolicyOption(func(o *options) {
+		o.ref().tierPolicy = policy
+	})
+}
+
+// WithLevel sets the compression level of compressing encodings, from
+// zlib.HuffmanOnly to zlib.BestCompression for the zlib family. Zstd,
+// Brotli and LZ4 document their own ranges; Snappy has no levels.
+func WithLevel(level int) LevelOption {
+	return levelOption(func(o *options) {
+		o.level = level
+		o.hasLevel = true
+	})
+}
+
+// nameOr returns the configured name, or typeName when there is none.
+func (o options) nameOr(typeName string) string {
+	if o.name != "" {
+		return o.name
+	}
+	return typeName
+}
+
+// charsetOr returns the WithCharset charset, or charset when none was given.
+func (o options) charsetOr(charset string) string {
+	if o.charset != "" {
+		return o.charset
+	}
+	return charset
+}
+
+func (o options) indented() bool {
+	return o.prefix != "" || o.indent != ""
+}
+
+// levelOr returns the WithLevel level, or level when none was given.
+func (o options) levelOr(level int) int {
+	if o.hasLevel {
+		return o.level
+	}
+	return level
+}
+
+// tierPolicyOr returns the WithTierPolicy policy, or policy when none was
+// given.
+func (o options) tierPolicyOr(policy TierPolicy) TierPolicy {
+	if o.refs != nil && o.refs.tierPolicy != nil {
+		return o.refs.tierPolicy
+	}
+	return policy
+}
+
+// ref returns the settings behind refs, allocating them on first use.
+func (o *options) ref() *optionRefs {
+	if o.refs == nil {
+		o.refs = new(optionRefs)
+	}
+	return o.refs
+}
+
+// dictionary returns the WithDictionary dictionary, or nil.
+func (o options) dictionary() []byte {
+	if o.refs == nil {
+		return nil
+	}
+	return o.refs.dictionary
+}
+
+// associatedData returns the WithAssociatedData associated data, or nil.
+func (o options) associatedData() []byte {
+	if o.refs == nil {
+		return nil
+	}
+	return o.refs.associatedData
+}
+
+// signingKey returns the WithSigningKey key, or nil.
+func (o options) signingKey() crypto.PrivateKey {
+	if o.refs == nil {
+		return nil
+	}
+	return o.refs.signingKey
+}
@@ -0,0 +1,164 @@
+package encodingx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrPercentEncodingWrongValueType = errors.New("encoding percent converts on wrong type value")
+	ErrPercentEncodingInvalidData    = errors.New("encoding percent invalid escape")
+	ErrPercentEncodingInvalidMode    = errors.New("encoding percent invalid mode")
+)
+
+// PercentMode selects which characters PercentEncoding leaves unescaped.
+// Every mode keeps the RFC 3986 unreserved characters ALPHA, DIGIT and
+// "-._~" and escapes space as %20.
+type PercentMode int
+
+const (
+	// PercentComponent escapes everything else, for any URL component.
+	PercentComponent PercentMode = iota
+	// PercentPath keeps what RFC 3986 allows in a path, "/" included.
+	PercentPath
+	// PercentQuery keeps what RFC 3986 allows in a query, except "&", "="
+	// and "+" which delimit form values.
+	PercentQuery
+)
+
+var percentModeName = map[PercentMode]string{
+	PercentComponent: "component",
+	PercentPath:      "path",
+	PercentQuery:     "query",
+}
+
+func (m PercentMode) String() string {
+	if s, ok := percentModeName[m]; ok {
+		return s
+	}
+	return fmt.Sprintf("percentModeName=%d?", int(m))
+}
+
+func (m PercentMode) valid() bool {
+	return m >= 0 && int(m) < len(percentKeep)
+}
+
+// percentKeep reports per mode which bytes are written unescaped.
+var percentKeep = [...][256]bool{
+	PercentComponent: newPercentKeep(""),
+	PercentPath:      newPercentKeep("!$&'()*+,;=:@/"),
+	PercentQuery:     newPercentKeep("!$'()*,;:@/?"),
+}
+
+func newPercentKeep(extra string) [256]bool {
+	var keep [256]bool
+	for c := 'a'; c <= 'z'; c++ {
+		keep[c] = true
+		keep[c-'a'+'A'] = true
+	}
+	for c := '0'; c <= '9'; c++ {
+		keep[c] = true
+	}
+	for _, c := range "-._~" + extra {
+		keep[c] = true
+	}
+	return keep
+}
+
+func init() {
+	register(NewPercentEncoding())
+	register(NewPercentEncoding(WithName("PercentEncodingPath"), WithPercentMode(PercentPath)))
+	register(NewPercentEncoding(WithName("PercentEncodingQuery"), WithPercentMode(PercentQuery)))
+}
+
+// ============================================================================
+// PercentEncoding - RFC 3986 percent-encoding of raw bytes
+// Unmarshal decodes every %XX escape and leaves other bytes, "+" included,
+// as they are, so it reads the output of every mode alike.
+// ============================================================================
+
+type PercentEncoding struct {
+	options
+}
+
+// NewPercentEncoding creates a PercentEncoding, honoring WithName and
+// WithPercentMode.
+func NewPercentEncoding(opts ...PercentOption) *PercentEncoding {
+	return &PercentEncoding{
+		options: newOptions(opts, PercentOption.applyPercent),
+	}
+}
+
+func (p PercentEncoding) String() string {
+	return p.nameOr(reflectx.TypeName(p))
+}
+
+func (PercentEncoding) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (p PercentEncoding) Marshal(v interface{}) ([]byte, error) {
+	return p.AppendMarshal(nil, v)
+}
+
+func (p PercentEncoding) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrPercentEncodingWrongValueType
+	}
+	keep := &percentKeep[p.percentMode]
+	const upperhex = "0123456789ABCDEF"
+	for _, c := range data {
+		if keep[c] {
+			dst = append(dst, c)
+		} else {
+			dst = append(dst, '%', upperhex[c>>4], upperhex[c&15])
+		}
+	}
+	return dst, nil
+}
+
+func (PercentEncoding) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decoded := make([]byte, 0, len(data))
+		for i := 0; i < len(data); i++ {
+			if data[i] != '%' {
+				decoded = append(decoded, data[i])
+				continue
+			}
+			if i+2 >= len(data) {
+				return ErrPercentEncodingInvalidData
+			}
+			hi, ok1 := unhex(data[i+1])
+			lo, ok2 := unhex(data[i+2])
+			if !ok1 || !ok2 {
+				return ErrPercentEncodingInvalidData
+			}
+			decoded = append(decoded, hi<<4|lo)
+			i += 2
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrPercentEncodingWrongValueType
+	}
+}
+
+func (p PercentEncoding) Reverse() Encoding {
+	return p
+}
+
+func unhex(c byte) (byte, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return c - '0', true
+	case c >= 'a' && c <= 'f':
+		return c - 'a' + 10, true
+	case c >= 'A' && c <= 'F':
+		return c - 'A' + 10, true
+	}
+	return 0, false
+}
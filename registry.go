@@ -0,0 +1,245 @@
+package encodingx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrEncodingDuplicateName = errors.New("encoding name is already registered")
+	ErrEncodingInvalidName   = errors.New("encoding name is invalid")
+	ErrEncodingNilEncoding   = errors.New("encoding cannot register nil encoding")
+)
+
+// Naming scheme
+//
+// Every encoding is registered under a name, and ChainEncoding resolves its
+// stages by that name. A name starts with an ASCII letter and continues with
+// ASCII letters, digits, '_' or '.'; names are case sensitive. Built-in
+// encodings are registered under their Go type name ("JSON", "Base64URL",
+// "HexZlib"). Third-party encodings should use a dotted vendor prefix such as
+// "acme.Packet" so they never collide with a future built-in.
+
+// ValidEncodingName reports whether name follows the naming scheme.
+func ValidEncodingName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for index, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case index > 0 && (r >= '0' && r <= '9' || r == '_' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+type EncodingSet map[string]Encoding
+
+func newEncodingSet() EncodingSet {
+	return make(map[string]Encoding)
+}
+
+// Registry is a concurrency-safe set of named encodings. A child registry
+// resolves names it does not hold itself through its parent, so tenants can
+// share the built-ins while adding or shadowing encodings of their own.
+type Registry struct {
+	mu     sync.RWMutex
+	set    EncodingSet
+	parent *Registry
+}
+
+// NewRegistry creates an empty registry without a parent.
+func NewRegistry() *Registry {
+	return &Registry{
+		set: newEncodingSet(),
+	}
+}
+
+// NewChild creates an empty registry that falls back to r.
+func (r *Registry) NewChild() *Registry {
+	child := NewRegistry()
+	child.parent = r
+	return child
+}
+
+// Parent returns the registry r falls back to, or nil.
+func (r *Registry) Parent() *Registry {
+	return r.parent
+}
+
+var defaultRegistry = NewRegistry()
+
+// DefaultRegistry returns the registry holding the built-in encodings,
+// used by the package-level functions and by NewChainEncoding.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// register is used by the built-in encodings from their init functions,
+// a name clash there is a programming error.
+func register(encodings ...Encoding) {
+	MustRegister(encodings...)
+}
+
+// Register adds encodings to the default registry.
+func Register(encodings ...Encoding) error {
+	return defaultRegistry.Register(encodings...)
+}
+
+// MustRegister adds encodings to the default registry and panics on failure.
+func MustRegister(encodings ...Encoding) {
+	defaultRegistry.MustRegister(encodings...)
+}
+
+// RegisterAlias adds an alias to the default registry.
+func RegisterAlias(alias, name string) error {
+	return defaultRegistry.RegisterAlias(alias, name)
+}
+
+// Lookup returns the encoding registered under name in the default registry.
+func Lookup(name string) (Encoding, error) {
+	return defaultRegistry.Lookup(name)
+}
+
+// Names returns the sorted names, aliases included, of the default registry.
+func Names() []string {
+	return defaultRegistry.Names()
+}
+
+// Unregister removes name from the default registry.
+func Unregister(name string) error {
+	return defaultRegistry.Unregister(name)
+}
+
+// Register adds encodings to r, each under the name returned by its String
+// method. Either all encodings are registered or, on the first invalid or
+// duplicate name, none of them. Only r itself is checked for duplicates,
+// so a child may shadow an encoding of its parent.
+func (r *Registry) Register(encodings ...Encoding) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.set.register(encodings...)
+}
+
+// MustRegister is like Register but panics if an encoding cannot be registered.
+func (r *Registry) MustRegister(encodings ...Encoding) {
+	if err := r.Register(encodings...); err != nil {
+		panic(err)
+	}
+}
+
+// RegisterAlias makes the encoding that name resolves to also reachable
+// under alias in r. The alias is bound to the encoding, not to the name,
+// so it keeps resolving if name is later unregistered.
+func (r *Registry) RegisterAlias(alias, name string) error {
+	encoding, err := r.Lookup(name)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.set.add(alias, encoding)
+}
+
+// Lookup returns the encoding registered under name in r or its ancestors.
+func (r *Registry) Lookup(name string) (Encoding, error) {
+	for registry := r; registry != nil; registry = registry.parent {
+		registry.mu.RLock()
+		encoding, err := registry.set.locateEncoding(name)
+		registry.mu.RUnlock()
+		if err == nil {
+			return encoding, nil
+		}
+	}
+	return nil, ErrEncodingMissingEncoding
+}
+
+// Names returns the sorted names, aliases included, resolvable through r.
+func (r *Registry) Names() []string {
+	union := newEncodingSet()
+	for registry := r; registry != nil; registry = registry.parent {
+		registry.mu.RLock()
+		for name, encoding := range registry.set {
+			if _, ok := union[name]; !ok {
+				union[name] = encoding
+			}
+		}
+		registry.mu.RUnlock()
+	}
+	return union.names()
+}
+
+// Unregister removes name from r. Encodings held by a parent are untouched.
+func (r *Registry) Unregister(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.set.unregister(name)
+}
+
+func (es EncodingSet) register(encodings ...Encoding) error {
+	pending := make(map[string]struct{}, len(encodings))
+	for _, encoding := range encodings {
+		if encoding == nil {
+			return ErrEncodingNilEncoding
+		}
+		name := encoding.String()
+		if err := es.checkName(name); err != nil {
+			return err
+		}
+		if _, ok := pending[name]; ok {
+			return fmt.Errorf("%w: %q", ErrEncodingDuplicateName, name)
+		}
+		pending[name] = struct{}{}
+	}
+	for _, encoding := range encodings {
+		es[encoding.String()] = encoding
+	}
+	return nil
+}
+
+func (es EncodingSet) add(name string, encoding Encoding) error {
+	if err := es.checkName(name); err != nil {
+		return err
+	}
+	es[name] = encoding
+	return nil
+}
+
+func (es EncodingSet) checkName(name string) error {
+	if !ValidEncodingName(name) {
+		return fmt.Errorf("%w: %q", ErrEncodingInvalidName, name)
+	}
+	if _, ok := es[name]; ok {
+		return fmt.Errorf("%w: %q", ErrEncodingDuplicateName, name)
+	}
+	return nil
+}
+
+func (es EncodingSet) locateEncoding(name string) (Encoding, error) {
+	if e, ok := es[name]; ok {
+		return e, nil
+	}
+	return nil, ErrEncodingMissingEncoding
+}
+
+func (es EncodingSet) names() []string {
+	names := make([]string, 0, len(es))
+	for name := range es {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (es EncodingSet) unregister(name string) error {
+	if _, ok := es[name]; !ok {
+		return ErrEncodingMissingEncoding
+	}
+	delete(es, name)
+	return nil
+}
@@ -0,0 +1,334 @@
+package encodingx
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/aura-studio/reflectx"
+)
+
+var (
+	ErrEd25519SignWrongValueType = errors.New("encoding Ed25519Sign converts on wrong type value")
+	ErrECDSASignWrongValueType   = errors.New("encoding ECDSASign converts on wrong type value")
+	ErrSignNoPrivateKey          = errors.New("encoding verifier has no private key to sign with")
+	ErrSignInvalidKey            = errors.New("encoding signature key of wrong type")
+)
+
+// PublicKeyProvider supplies the public keys signatures are verified with,
+// by the key ID the signature names.
+type PublicKeyProvider interface {
+	PublicKey(id string) (crypto.PublicKey, error)
+}
+
+// PublicKeys is a fixed PublicKeyProvider, holding ed25519.PublicKey or
+// *ecdsa.PublicKey values by key ID. As a JWTKeySet it may also hold
+// *rsa.PublicKey values, and []byte secrets for HS256.
+type PublicKeys map[string]crypto.PublicKey
+
+func (p PublicKeys) PublicKey(id string) (crypto.PublicKey, error) {
+	key, ok := p[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrKeyNotFound, id)
+	}
+	return key, nil
+}
+
+// verifyKeys holds the PublicKeyProvider of a signature encoding behind a
+// pointer, which keeps the encodings comparable with ==, as PublicKeys is a
+// map.
+type verifyKeys struct {
+	provider PublicKeyProvider
+}
+
+func newVerifyKeys(keys PublicKeyProvider) *verifyKeys {
+	if keys == nil {
+		return nil
+	}
+	return &verifyKeys{provider: keys}
+}
+
+func (k *verifyKeys) keys() PublicKeyProvider {
+	if k == nil {
+		return nil
+	}
+	return k.provider
+}
+
+// The signature encodings come as a signer, holding a private key, and a
+// verifier, holding public keys only. Reverse of a signer is the verifier
+// of its key, so that nodes which only verify never see a private key. As
+// they need keys, neither is registered by default.
+
+// ============================================================================
+// Ed25519Sign - data followed by its Ed25519 signature (RFC 8032)
+// Format: [1 byte key ID length] + [key ID] + [data] + [64 bytes signature]
+// The signature covers the key ID and the data.
+// ============================================================================
+
+type Ed25519Sign struct {
+	options
+	id      string
+	private *ed25519.PrivateKey
+	public  *verifyKeys
+}
+
+// NewEd25519Sign creates an Ed25519Sign signer, signing with a copy of key
+// under id, honoring WithName. It verifies with the public half of key.
+// Marshal fails with ErrSignNoPrivateKey if key is nil, and with
+// ErrSignInvalidKey if it has the wrong size.
+func NewEd25519Sign(id string, key ed25519.PrivateKey, opts ...Option) *Ed25519Sign {
+	e := &Ed25519Sign{
+		options: newOptions(opts, Option.apply),
+		id:      id,
+	}
+	if key != nil {
+		private := slices.Clone(key)
+		e.private = &private
+	}
+	if len(key) == ed25519.PrivateKeySize {
+		e.public = newVerifyKeys(PublicKeys{id: key.Public()})
+	}
+	return e
+}
+
+// NewEd25519Verify creates an Ed25519Sign verifier, honoring WithName. Its
+// Marshal fails with ErrSignNoPrivateKey.
+func NewEd25519Verify(keys PublicKeyProvider, opts ...Option) *Ed25519Sign {
+	return &Ed25519Sign{
+		options: newOptions(opts, Option.apply),
+		public:  newVerifyKeys(keys),
+	}
+}
+
+func (e Ed25519Sign) String() string {
+	return e.nameOr(reflectx.TypeName(e))
+}
+
+func (Ed25519Sign) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (e Ed25519Sign) Marshal(v interface{}) ([]byte, error) {
+	return e.AppendMarshal(nil, v)
+}
+
+func (e Ed25519Sign) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrEd25519SignWrongValueType
+	}
+	if e.private == nil {
+		return nil, ErrSignNoPrivateKey
+	}
+	if len(*e.private) != ed25519.PrivateKeySize {
+		return nil, ErrSignInvalidKey
+	}
+	return appendSigned(dst, e.id, data, func(message []byte) ([]byte, error) {
+		return ed25519.Sign(*e.private, message), nil
+	})
+}
+
+// Unmarshal strips the signature off data once it verifies, and fails with
+// ErrAuthentication if data was not signed by one of the keys, or was
+// altered since.
+func (e Ed25519Sign) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		verified, err := openSigned(data, ed25519.SignatureSize, e.public.keys(), func(key crypto.PublicKey, message, signature []byte) (bool, error) {
+			public, ok := key.(ed25519.PublicKey)
+			if !ok || len(public) != ed25519.PublicKeySize {
+				return false, ErrSignInvalidKey
+			}
+			return ed25519.Verify(public, message, signature), nil
+		})
+		if err != nil {
+			return err
+		}
+		v.Data = verified
+		return nil
+	default:
+		return ErrEd25519SignWrongValueType
+	}
+}
+
+// Reverse returns the verifier of e, which holds no private key.
+func (e Ed25519Sign) Reverse() Encoding {
+	return Ed25519Sign{
+		options: e.options,
+		public:  e.public,
+	}
+}
+
+// ============================================================================
+// ECDSASign - data followed by its ECDSA P-256 signature over SHA-256
+// Format: [1 byte key ID length] + [key ID] + [data] + [32 bytes r] + [32 bytes s]
+// The signature covers the key ID and the data.
+// ============================================================================
+
+// ecdsaSignatureSize is the size of r and s together on P-256.
+const ecdsaSignatureSize = 64
+
+type ECDSASign struct {
+	options
+	id      string
+	private *ecdsa.PrivateKey
+	public  *verifyKeys
+}
+
+// NewECDSASign creates an ECDSASign signer, signing with key under id,
+// honoring WithName. It verifies with the public half of key. Marshal fails
+// with ErrSignNoPrivateKey if key is nil, and with ErrSignInvalidKey unless
+// key is on P-256.
+func NewECDSASign(id string, key *ecdsa.PrivateKey, opts ...Option) *ECDSASign {
+	e := &ECDSASign{
+		options: newOptions(opts, Option.apply),
+		id:      id,
+		private: key,
+	}
+	if key != nil {
+		e.public = newVerifyKeys(PublicKeys{id: &key.PublicKey})
+	}
+	return e
+}
+
+// NewECDSAVerify creates an ECDSASign verifier, honoring WithName. Its
+// Marshal fails with ErrSignNoPrivateKey.
+func NewECDSAVerify(keys PublicKeyProvider, opts ...Option) *ECDSASign {
+	return &ECDSASign{
+		options: newOptions(opts, Option.apply),
+		public:  newVerifyKeys(keys),
+	}
+}
+
+func (e ECDSASign) String() string {
+	return e.nameOr(reflectx.TypeName(e))
+}
+
+func (ECDSASign) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (e ECDSASign) Marshal(v interface{}) ([]byte, error) {
+	return e.AppendMarshal(nil, v)
+}
+
+func (e ECDSASign) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrECDSASignWrongValueType
+	}
+	if e.private == nil {
+		return nil, ErrSignNoPrivateKey
+	}
+	if e.private.Curve != elliptic.P256() || e.private.D == nil {
+		return nil, ErrSignInvalidKey
+	}
+	return appendSigned(dst, e.id, data, func(message []byte) ([]byte, error) {
+		return signP256(e.private, message)
+	})
+}
+
+// Unmarshal strips the signature off data once it verifies, and fails with
+// ErrAuthentication if data was not signed by one of the keys, or was
+// altered since.
+func (e ECDSASign) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		verified, err := openSigned(data, ecdsaSignatureSize, e.public.keys(), func(key crypto.PublicKey, message, signature []byte) (bool, error) {
+			public, ok := key.(*ecdsa.PublicKey)
+			if !ok || public.Curve != elliptic.P256() {
+				return false, ErrSignInvalidKey
+			}
+			return verifyP256(public, message, signature), nil
+		})
+		if err != nil {
+			return err
+		}
+		v.Data = verified
+		return nil
+	default:
+		return ErrECDSASignWrongValueType
+	}
+}
+
+// Reverse returns the verifier of e, which holds no private key.
+func (e ECDSASign) Reverse() Encoding {
+	return ECDSASign{
+		options: e.options,
+		public:  e.public,
+	}
+}
+
+// appendSigned appends the key ID header, data and the signature sign makes
+// of both.
+func appendSigned(dst []byte, id string, data []byte, sign func(message []byte) ([]byte, error)) ([]byte, error) {
+	start := len(dst)
+	dst, err := appendKeyID(dst, id)
+	if err != nil {
+		return nil, err
+	}
+	dst = append(dst, data...)
+	signature, err := sign(dst[start:])
+	if err != nil {
+		return nil, err
+	}
+	return append(dst, signature...), nil
+}
+
+// openSigned returns the data of an appendSigned envelope once verify
+// accepts its signature under the public key it names.
+func openSigned(data []byte, size int, keys PublicKeyProvider, verify func(key crypto.PublicKey, message, signature []byte) (bool, error)) ([]byte, error) {
+	if keys == nil {
+		return nil, ErrKeyNotFound
+	}
+	id, header, rest, ok := readKeyID(data)
+	if !ok || len(rest) < size {
+		return nil, ErrAuthentication
+	}
+	key, err := keys.PublicKey(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrAuthentication, err)
+	}
+	message := data[:len(header)+len(rest)-size]
+	ok, err = verify(key, message, data[len(message):])
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrAuthentication
+	}
+	return slices.Clone(message[len(header):]), nil
+}
+
+// signP256 signs the SHA-256 digest of message, returning r and s as two
+// 32 byte big-endian halves, as ES256 does.
+func signP256(key *ecdsa.PrivateKey, message []byte) ([]byte, error) {
+	digest := sha256.Sum256(message)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	signature := make([]byte, ecdsaSignatureSize)
+	r.FillBytes(signature[:ecdsaSignatureSize/2])
+	s.FillBytes(signature[ecdsaSignatureSize/2:])
+	return signature, nil
+}
+
+// verifyP256 verifies a signature made by signP256.
+func verifyP256(key *ecdsa.PublicKey, message, signature []byte) bool {
+	if len(signature) != ecdsaSignatureSize {
+		return false
+	}
+	digest := sha256.Sum256(message)
+	r := new(big.Int).SetBytes(signature[:ecdsaSignatureSize/2])
+	s := new(big.Int).SetBytes(signature[ecdsaSignatureSize/2:])
+	return ecdsa.Verify(key, digest[:], r, s)
+}
@@ -0,0 +1,206 @@
+package encodingx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/aura-studio/reflectx"
+	"github.com/klauspost/compress/s2"
+)
+
+var (
+	ErrSnappyWrongValueType       = errors.New("encoding snappy converts on wrong type value")
+	ErrSnappyFramedWrongValueType = errors.New("encoding snappyFramed converts on wrong type value")
+)
+
+func init() {
+	register(NewSnappy())
+	register(NewSnappyFramed())
+}
+
+// ============================================================================
+// Snappy - a single Snappy block, which starts with its decompressed length
+// Snappy has no compression levels.
+// ============================================================================
+
+type Snappy struct {
+	options
+}
+
+// NewSnappy creates a Snappy encoding, honoring WithName and the
+// decompression limits.
+func NewSnappy(opts ...DecompressOption) *Snappy {
+	return &Snappy{
+		options: newOptions(opts, DecompressOption.applyDecompress),
+	}
+}
+
+func (s Snappy) String() string {
+	return s.nameOr(reflectx.TypeName(s))
+}
+
+func (Snappy) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (s Snappy) Marshal(v interface{}) ([]byte, error) {
+	return s.AppendMarshal(nil, v)
+}
+
+func (Snappy) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrSnappyWrongValueType
+	}
+	maxLen := s2.MaxEncodedLen(len(data))
+	if maxLen < 0 {
+		return nil, s2.ErrTooLarge
+	}
+	start := len(dst)
+	dst = append(dst, make([]byte, maxLen)...)
+	encoded := s2.EncodeSnappy(dst[start:], data)
+	return dst[:start+len(encoded)], nil
+}
+
+func (s Snappy) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		// Check the length the block starts with before it is allocated
+		size, err := s2.DecodedLen(data)
+		if err != nil {
+			return err
+		}
+		if err := s.decompressLimits().check(int64(size), int64(len(data))); err != nil {
+			return err
+		}
+		decoded, err := s2.Decode(nil, data)
+		if err != nil {
+			return err
+		}
+		v.Data = decoded
+		return nil
+	default:
+		return ErrSnappyWrongValueType
+	}
+}
+
+func (s Snappy) Reverse() Encoding {
+	return s
+}
+
+// ============================================================================
+// SnappyFramed - the Snappy framing format, with CRC-32C checksums
+// ============================================================================
+
+type SnappyFramed struct {
+	options
+}
+
+// NewSnappyFramed creates a SnappyFramed encoding, honoring WithName and
+// the decompression limits.
+func NewSnappyFramed(opts ...DecompressOption) *SnappyFramed {
+	return &SnappyFramed{
+		options: newOptions(opts, DecompressOption.applyDecompress),
+	}
+}
+
+func (s SnappyFramed) String() string {
+	return s.nameOr(reflectx.TypeName(s))
+}
+
+func (SnappyFramed) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (s SnappyFramed) Marshal(v interface{}) ([]byte, error) {
+	return s.AppendMarshal(nil, v)
+}
+
+func (s SnappyFramed) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	return s.appendMarshal(context.Background(), dst, v)
+}
+
+// MarshalContext is like Marshal but stops compressing once ctx is done.
+func (s SnappyFramed) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	return s.appendMarshal(ctx, nil, v)
+}
+
+func (SnappyFramed) appendMarshal(ctx context.Context, dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrSnappyFramedWrongValueType
+	}
+	return snappyFramedFormat.appendCompress(ctx, dst, data, 0, nil)
+}
+
+func (s SnappyFramed) Unmarshal(data []byte, v interface{}) error {
+	return s.UnmarshalContext(context.Background(), data, v)
+}
+
+// UnmarshalContext is like Unmarshal but stops decompressing once ctx is
+// done.
+func (s SnappyFramed) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decompressed, err := snappyFramedFormat.decompress(ctx, data, nil, s.decompressLimits())
+		if err != nil {
+			return err
+		}
+		v.Data = decompressed
+		return nil
+	default:
+		return ErrSnappyFramedWrongValueType
+	}
+}
+
+func (SnappyFramed) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(snappyFramedFormat.newStreamWriter(w, 0, nil), ErrSnappyFramedWrongValueType)
+}
+
+func (s SnappyFramed) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(snappyFramedFormat.newStreamReader(r, nil, s.decompressLimits()), ErrSnappyFramedWrongValueType)
+}
+
+func (s SnappyFramed) Reverse() Encoding {
+	return s
+}
+
+var snappyFramedFormat = &compressFormat{
+	newWriter: func(w io.Writer, _ int, _ []byte) (compressWriter, error) {
+		return s2.NewWriter(w, s2.WriterSnappyCompat(), s2.WriterConcurrency(1)), nil
+	},
+	newReader: func(r io.Reader, _ []byte) (io.ReadCloser, error) {
+		sr := snappyReaderPool.Get().(*s2.Reader)
+		sr.Reset(r)
+		return &snappyFramedReader{sr: sr}, nil
+	},
+}
+
+// snappyReaderPool holds readers with their block buffers, which cost more
+// to allocate than a small message costs to decode.
+var snappyReaderPool = sync.Pool{
+	New: func() interface{} {
+		// Snappy chunks hold at most 64 KiB, S2 streams may hold more
+		return s2.NewReader(nil, s2.ReaderMaxBlockSize(64<<10))
+	},
+}
+
+// snappyFramedReader returns its reader to snappyReaderPool on Close.
+type snappyFramedReader struct {
+	sr *s2.Reader
+}
+
+func (r *snappyFramedReader) Read(p []byte) (int, error) {
+	return r.sr.Read(p)
+}
+
+func (r *snappyFramedReader) Close() error {
+	if r.sr != nil {
+		r.sr.Reset(nil)
+		snappyReaderPool.Put(r.sr)
+		r.sr = nil
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package encodingx
+
+import (
+	"io"
+)
+
+// Encoder writes encoded values to an underlying writer. Close flushes
+// whatever the encoder still buffers; it does not close the underlying writer.
+//
+// Encoders of bytes style encodings also implement io.Writer, writing raw
+// bytes through the transformation, which is how ChainEncoding links them.
+type Encoder interface {
+	Encode(v interface{}) error
+	Close() error
+}
+
+// Decoder reads decoded values from an underlying reader.
+//
+// Decoders of bytes style encodings also implement io.Reader, yielding the
+// decoded bytes, which is how ChainEncoding links them.
+type Decoder interface {
+	Decode(v interface{}) error
+}
+
+// StreamEncoding is implemented by encodings that can work on streams
+// instead of whole byte slices.
+type StreamEncoding interface {
+	Encoding
+	NewEncoder(w io.Writer) Encoder
+	NewDecoder(r io.Reader) Decoder
+}
+
+// streamEncoder adapts an encode function and an optional flush to Encoder.
+type streamEncoder struct {
+	encode func(v interface{}) error
+	close  func() error
+}
+
+func (e streamEncoder) Encode(v interface{}) error {
+	return e.encode(v)
+}
+
+func (e streamEncoder) Close() error {
+	if e.close == nil {
+		return nil
+	}
+	return e.close()
+}
+
+// streamDecoder adapts a decode function to Decoder.
+type streamDecoder struct {
+	decode func(v interface{}) error
+}
+
+func (d streamDecoder) Decode(v interface{}) error {
+	return d.decode(v)
+}
+
+// bytesEncoder is the Encoder of bytes style encodings. Raw bytes written
+// to it pass through w, which applies the transformation; closing it
+// closes w, never the writer underneath w.
+type bytesEncoder struct {
+	w         io.WriteCloser
+	wrongType error
+}
+
+func newBytesEncoder(w io.WriteCloser, wrongType error) *bytesEncoder {
+	return &bytesEncoder{
+		w:         w,
+		wrongType: wrongType,
+	}
+}
+
+func (e *bytesEncoder) Write(p []byte) (int, error) {
+	return e.w.Write(p)
+}
+
+func (e *bytesEncoder) Encode(v interface{}) error {
+	data, err := toBytes(v)
+	if err != nil {
+		return e.wrongType
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *bytesEncoder) Close() error {
+	return e.w.Close()
+}
+
+// nopWriteCloser is for transformations that buffer nothing.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// bytesDecoder is the Decoder of bytes style encodings. Reading from it
+// yields the bytes decoded from r.
+type bytesDecoder struct {
+	r         io.Reader
+	wrongType error
+}
+
+func newBytesDecoder(r io.Reader, wrongType error) *bytesDecoder {
+	return &bytesDecoder{
+		r:         r,
+		wrongType: wrongType,
+	}
+}
+
+func (d *bytesDecoder) Read(p []byte) (int, error) {
+	return d.r.Read(p)
+}
+
+func (d *bytesDecoder) Decode(v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		data, err := io.ReadAll(d.r)
+		if err != nil {
+			return err
+		}
+		v.Data = data
+		return nil
+	default:
+		return d.wrongType
+	}
+}
+
+// writeBytes writes []byte, Bytes and *Bytes values unchanged and reports
+// whether v was one of them; struct encodings use it for their Bytes pass-through.
+func writeBytes(w io.Writer, v interface{}) (bool, error) {
+	switch v := v.(type) {
+	case []byte:
+		_, err := w.Write(v)
+		return true, err
+	case Bytes:
+		_, err := w.Write(v.Data)
+		return true, err
+	case *Bytes:
+		_, err := w.Write(v.Data)
+		return true, err
+	default:
+		return false, nil
+	}
+}
@@ -1,6 +1,7 @@
 package encodingx_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/aura-studio/encodingx"
@@ -424,7 +425,7 @@ func TestChainEncodingMarshalMiddleEncoderStyleError(t *testing.T) {
 
 	// 序列化应该返回 ErrWrongEncodingStyle 错误
 	_, err := chain.Marshal(original)
-	if err != encodingx.ErrWrongEncodingStyle {
+	if !errors.Is(err, encodingx.ErrWrongEncodingStyle) {
 		t.Errorf("Expected ErrWrongEncodingStyle, got %v", err)
 	}
 }
@@ -460,7 +461,7 @@ func TestChainEncodingUnmarshalMiddleDecoderStyleError(t *testing.T) {
 	// 反序列化应该返回 ErrWrongEncodingStyle 错误
 	var result TestStruct
 	err = invalidChain.Unmarshal(data, &result)
-	if err != encodingx.ErrWrongEncodingStyle {
+	if !errors.Is(err, encodingx.ErrWrongEncodingStyle) {
 		t.Errorf("Expected ErrWrongEncodingStyle, got %v", err)
 	}
 }
@@ -483,7 +484,7 @@ func TestChainEncodingMarshalYAMLMiddleError(t *testing.T) {
 
 	// 序列化应该返回 ErrWrongEncodingStyle 错误
 	_, err := chain.Marshal(original)
-	if err != encodingx.ErrWrongEncodingStyle {
+	if !errors.Is(err, encodingx.ErrWrongEncodingStyle) {
 		t.Errorf("Expected ErrWrongEncodingStyle, got %v", err)
 	}
 }
@@ -506,7 +507,7 @@ func TestChainEncodingMarshalXMLMiddleError(t *testing.T) {
 
 	// 序列化应该返回 ErrWrongEncodingStyle 错误
 	_, err := chain.Marshal(original)
-	if err != encodingx.ErrWrongEncodingStyle {
+	if !errors.Is(err, encodingx.ErrWrongEncodingStyle) {
 		t.Errorf("Expected ErrWrongEncodingStyle, got %v", err)
 	}
 }
@@ -671,7 +672,7 @@ func TestChainEncodingMarshalUnknownEncoder(t *testing.T) {
 	if err == nil {
 		t.Error("Marshal with unknown encoder should return error")
 	}
-	if err != encodingx.ErrEncodingMissingEncoding {
+	if !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
 		t.Errorf("Expected ErrEncodingMissingEncoding, got %v", err)
 	}
 }
@@ -708,7 +709,7 @@ func TestChainEncodingUnmarshalUnknownDecoder(t *testing.T) {
 	if err == nil {
 		t.Error("Unmarshal with unknown decoder should return error")
 	}
-	if err != encodingx.ErrEncodingMissingEncoding {
+	if !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
 		t.Errorf("Expected ErrEncodingMissingEncoding, got %v", err)
 	}
 }
@@ -0,0 +1,262 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// AESGCM / ChaCha20Poly1305 认证加密测试
+// ============================================================================
+
+var aeadKey = bytes.Repeat([]byte{0x42}, 32)
+
+// testKeyRing 是测试用的多密钥 KeyProvider，用于模拟密钥轮换
+type testKeyRing struct {
+	current string
+	keys    map[string][]byte
+}
+
+func (r testKeyRing) CurrentKey() (string, []byte, error) {
+	return r.current, r.keys[r.current], nil
+}
+
+func (r testKeyRing) Key(id string) ([]byte, error) {
+	key, ok := r.keys[id]
+	if !ok {
+		return nil, encodingx.ErrKeyNotFound
+	}
+	return key, nil
+}
+
+// aeadCodecs 返回两种认证加密编码，opts 传给每个构造函数
+func aeadCodecs(keys encodingx.KeyProvider, opts ...encodingx.AEADOption) []encodingx.Encoding {
+	return []encodingx.Encoding{
+		encodingx.NewAESGCM(keys, opts...),
+		encodingx.NewChaCha20Poly1305(keys, opts...),
+	}
+}
+
+// TestAEADRoundTrip 测试加密解密往返，且每次使用不同的随机 nonce
+func TestAEADRoundTrip(t *testing.T) {
+	for _, encoding := range aeadCodecs(encodingx.StaticKey(aeadKey)) {
+		t.Run(encoding.String(), func(t *testing.T) {
+			for _, plain := range [][]byte{nil, []byte("x"), compressPayload} {
+				first, err := encoding.Marshal(plain)
+				if err != nil {
+					t.Fatalf("Marshal failed: %v", err)
+				}
+				second, err := encoding.Marshal(plain)
+				if err != nil {
+					t.Fatalf("Marshal failed: %v", err)
+				}
+				if bytes.Equal(first, second) {
+					t.Error("two Marshal calls gave the same output")
+				}
+				// 1 字节密钥 ID 长度 + 12 字节 nonce + 16 字节 tag
+				if len(first) != len(plain)+1+12+16 {
+					t.Errorf("unexpected length %d for %d bytes", len(first), len(plain))
+				}
+				if len(plain) > 8 && bytes.Contains(first, plain) {
+					t.Error("output contains the plaintext")
+				}
+
+				var decoded encodingx.Bytes
+				if err := encoding.Unmarshal(first, &decoded); err != nil {
+					t.Fatalf("Unmarshal failed: %v", err)
+				}
+				if !bytes.Equal(decoded.Data, plain) {
+					t.Errorf("round trip mismatch for %d bytes", len(plain))
+				}
+			}
+		})
+	}
+}
+
+// TestAEADTamper 测试任意字节被篡改或截断时返回 ErrAuthentication
+func TestAEADTamper(t *testing.T) {
+	for _, encoding := range aeadCodecs(encodingx.StaticKey(aeadKey)) {
+		t.Run(encoding.String(), func(t *testing.T) {
+			data, err := encoding.Marshal([]byte("transfer 100 to alice"))
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var decoded encodingx.Bytes
+			for i := range data {
+				tampered := bytes.Clone(data)
+				tampered[i] ^= 0x01
+				if err := encoding.Unmarshal(tampered, &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+					t.Errorf("byte %d: expected ErrAuthentication, got %v", i, err)
+				}
+			}
+			for _, n := range []int{0, 1, 12, len(data) - 1} {
+				if err := encoding.Unmarshal(data[:n], &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+					t.Errorf("truncated to %d: expected ErrAuthentication, got %v", n, err)
+				}
+			}
+		})
+	}
+}
+
+// TestAEADWrongKey 测试用其他密钥解密失败
+func TestAEADWrongKey(t *testing.T) {
+	other := bytes.Repeat([]byte{0x24}, 32)
+	sealers := aeadCodecs(encodingx.StaticKey(aeadKey))
+	openers := aeadCodecs(encodingx.StaticKey(other))
+	for i := range sealers {
+		data, err := sealers[i].Marshal([]byte("secret"))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var decoded encodingx.Bytes
+		if err := openers[i].Unmarshal(data, &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+			t.Errorf("%s: expected ErrAuthentication, got %v", sealers[i], err)
+		}
+	}
+}
+
+// TestAEADAssociatedData 测试关联数据参与认证但不写入输出
+func TestAEADAssociatedData(t *testing.T) {
+	keys := encodingx.StaticKey(aeadKey)
+	sealers := aeadCodecs(keys, encodingx.WithAssociatedData([]byte("user:1")))
+	same := aeadCodecs(keys, encodingx.WithAssociatedData([]byte("user:1")))
+	others := aeadCodecs(keys, encodingx.WithAssociatedData([]byte("user:2")))
+	none := aeadCodecs(keys)
+	for i := range sealers {
+		data, err := sealers[i].Marshal([]byte("secret"))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if bytes.Contains(data, []byte("user:1")) {
+			t.Errorf("%s: output contains the associated data", sealers[i])
+		}
+		var decoded encodingx.Bytes
+		if err := same[i].Unmarshal(data, &decoded); err != nil || string(decoded.Data) != "secret" {
+			t.Errorf("%s: Unmarshal failed: %v", sealers[i], err)
+		}
+		if err := others[i].Unmarshal(data, &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+			t.Errorf("%s: other associated data expected ErrAuthentication, got %v", sealers[i], err)
+		}
+		if err := none[i].Unmarshal(data, &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+			t.Errorf("%s: missing associated data expected ErrAuthentication, got %v", sealers[i], err)
+		}
+	}
+}
+
+// TestAEADKeyRotation 测试轮换后旧密钥加密的数据仍可解密
+func TestAEADKeyRotation(t *testing.T) {
+	old := testKeyRing{current: "2024", keys: map[string][]byte{"2024": aeadKey}}
+	rotated := testKeyRing{current: "2025", keys: map[string][]byte{
+		"2024": aeadKey,
+		"2025": bytes.Repeat([]byte{0x07}, 32),
+	}}
+	retired := testKeyRing{current: "2025", keys: map[string][]byte{"2025": rotated.keys["2025"]}}
+
+	sealers := aeadCodecs(old)
+	openers := aeadCodecs(rotated)
+	stale := aeadCodecs(retired)
+	for i := range sealers {
+		data, err := sealers[i].Marshal([]byte("secret"))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if !bytes.HasPrefix(data, []byte("\x042024")) {
+			t.Errorf("%s: key ID missing from header % x", sealers[i], data[:5])
+		}
+		var decoded encodingx.Bytes
+		if err := openers[i].Unmarshal(data, &decoded); err != nil || string(decoded.Data) != "secret" {
+			t.Errorf("%s: Unmarshal after rotation failed: %v", sealers[i], err)
+		}
+		err = stale[i].Unmarshal(data, &decoded)
+		if !errors.Is(err, encodingx.ErrAuthentication) || !errors.Is(err, encodingx.ErrKeyNotFound) {
+			t.Errorf("%s: retired key expected ErrAuthentication and ErrKeyNotFound, got %v", sealers[i], err)
+		}
+
+		fresh, err := openers[i].Marshal([]byte("secret"))
+		if err != nil || !bytes.HasPrefix(fresh, []byte("\x042025")) {
+			t.Errorf("%s: Marshal did not use the current key: %v", sealers[i], err)
+		}
+	}
+}
+
+// TestAEADInvalidKey 测试非法密钥长度和缺失的 KeyProvider
+func TestAEADInvalidKey(t *testing.T) {
+	for _, encoding := range aeadCodecs(encodingx.StaticKey([]byte("short"))) {
+		if _, err := encoding.Marshal([]byte("secret")); err == nil {
+			t.Errorf("%s: expected error for a 5 byte key", encoding)
+		}
+	}
+	for _, encoding := range aeadCodecs(nil) {
+		if _, err := encoding.Marshal([]byte("secret")); !errors.Is(err, encodingx.ErrKeyNotFound) {
+			t.Errorf("%s: expected ErrKeyNotFound, got %v", encoding, err)
+		}
+	}
+	// AES-128 与 AES-192 密钥
+	for _, size := range []int{16, 24} {
+		encoding := encodingx.NewAESGCM(encodingx.StaticKey(aeadKey[:size]))
+		data, err := encoding.Marshal([]byte("secret"))
+		if err != nil {
+			t.Fatalf("%d byte key: Marshal failed: %v", size, err)
+		}
+		var decoded encodingx.Bytes
+		if err := encoding.Unmarshal(data, &decoded); err != nil || string(decoded.Data) != "secret" {
+			t.Errorf("%d byte key: Unmarshal failed: %v", size, err)
+		}
+	}
+}
+
+// TestAEADChain 测试在注册表中注册后用于链式编码
+func TestAEADChain(t *testing.T) {
+	registry := encodingx.DefaultRegistry().NewChild()
+	registry.MustRegister(
+		encodingx.NewAESGCM(encodingx.StaticKey(aeadKey)),
+		encodingx.NewChaCha20Poly1305(encodingx.StaticKey(aeadKey)),
+	)
+	original := TestStruct{Integer: 20, String: "sealed", Bool: true, Float: 0.25}
+	for _, name := range []string{"AESGCM", "ChaCha20Poly1305"} {
+		chain, err := registry.ParseChain("MsgPack|" + name + "|Base64URL")
+		if err != nil {
+			t.Fatalf("ParseChain failed: %v", err)
+		}
+		data, err := chain.Marshal(original)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", name, err)
+		}
+		var result TestStruct
+		if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+			t.Errorf("%s: chain round trip failed: %v", name, err)
+		}
+
+		tampered := bytes.Clone(data)
+		tampered[len(tampered)/2] ^= 'A' ^ 'B'
+		if err := chain.Unmarshal(tampered, &result); err == nil {
+			t.Errorf("%s: tampered chain input expected error", name)
+		}
+	}
+	if _, err := encodingx.Lookup("AESGCM"); err == nil {
+		t.Error("AESGCM should not be registered by default")
+	}
+}
+
+// BenchmarkAEAD 基准测试加密与解密
+func BenchmarkAEAD(b *testing.B) {
+	for _, encoding := range aeadCodecs(encodingx.StaticKey(aeadKey)) {
+		data, _ := encoding.Marshal(compressPayload)
+		b.Run(encoding.String()+"/Marshal", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = encoding.Marshal(compressPayload)
+			}
+		})
+		b.Run(encoding.String()+"/Unmarshal", func(b *testing.B) {
+			b.ReportAllocs()
+			var decoded encodingx.Bytes
+			for i := 0; i < b.N; i++ {
+				_ = encoding.Unmarshal(data, &decoded)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// AppendMarshaler 和池化缓冲区测试
+// ============================================================================
+
+var appendMarshalers = []interface {
+	encodingx.Encoding
+	encodingx.AppendMarshaler
+}{
+	encodingx.NewLazy(),
+	encodingx.NewBase64(),
+	encodingx.NewBase64URL(),
+	encodingx.NewCloudFrontURLSafe(),
+	encodingx.NewHex(),
+	encodingx.NewHexTier(),
+	encodingx.NewHexZlib(),
+}
+
+// TestAppendMarshalMatchesMarshal 测试 AppendMarshal 保留前缀并与 Marshal 输出一致
+func TestAppendMarshalMatchesMarshal(t *testing.T) {
+	input := []byte(strings.Repeat("append marshal ", 20))
+	prefix := []byte("prefix:")
+	for _, enc := range appendMarshalers {
+		t.Run(enc.String(), func(t *testing.T) {
+			expected, err := enc.Marshal(input)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			dst := append(make([]byte, 0, 4), prefix...)
+			out, err := enc.AppendMarshal(dst, encodingx.MakeBytes(input))
+			if err != nil {
+				t.Fatalf("AppendMarshal failed: %v", err)
+			}
+			if !bytes.HasPrefix(out, prefix) {
+				t.Errorf("AppendMarshal should keep dst prefix")
+			}
+			if !bytes.Equal(out[len(prefix):], expected) {
+				t.Errorf("AppendMarshal output differs from Marshal")
+			}
+
+			var result encodingx.Bytes
+			if err := enc.Unmarshal(out[len(prefix):], &result); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !bytes.Equal(result.Data, input) {
+				t.Errorf("round trip failed")
+			}
+
+			if _, err := enc.AppendMarshal(nil, 42); err == nil {
+				t.Error("AppendMarshal should reject wrong type value")
+			}
+		})
+	}
+}
+
+// TestAppendMarshalHexTierRand 测试 HexTierRand 的 AppendMarshal 可以被解码
+func TestAppendMarshalHexTierRand(t *testing.T) {
+	enc := encodingx.NewHexTierRand()
+	input := []byte(strings.Repeat("r", 100))
+	out, err := enc.AppendMarshal([]byte("xx"), input)
+	if err != nil {
+		t.Fatalf("AppendMarshal failed: %v", err)
+	}
+	if len(out)-2 != 256 {
+		t.Errorf("expected 128-byte tier, got %d hex chars", len(out)-2)
+	}
+	var result encodingx.Bytes
+	if err := enc.Unmarshal(out[2:], &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !bytes.Equal(result.Data, input) {
+		t.Errorf("round trip failed")
+	}
+	if !errors.Is(func() error { _, err := enc.AppendMarshal(nil, "s"); return err }(), encodingx.ErrHexWrongValueType) {
+		t.Error("expected ErrHexWrongValueType")
+	}
+}
+
+// TestChainPooledBuffers 测试池化缓冲区不会在多次调用之间串扰
+func TestChainPooledBuffers(t *testing.T) {
+	chain := encodingx.MustParseChain("JSON|Base64|Hex|Base64URL")
+	results := make([][]byte, 0, 50)
+	inputs := make([]TestStruct, 0, 50)
+	for i := 0; i < 50; i++ {
+		input := TestStruct{Integer: i, String: strings.Repeat("p", i*10)}
+		data, err := chain.Marshal(input)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		inputs = append(inputs, input)
+		results = append(results, data)
+	}
+	for i, data := range results {
+		var result TestStruct
+		if err := chain.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Unmarshal %d failed: %v", i, err)
+		}
+		if !inputs[i].Equal(result) {
+			t.Errorf("result %d was overwritten", i)
+		}
+	}
+}
+
+// ============================================================================
+// 基准测试：对比每次操作的内存分配
+// ============================================================================
+
+var benchmarkPayload = []byte(strings.Repeat(`{"code":0,"msg":"ok","url":"https://example.com/path"}`, 8))
+
+func benchmarkMarshal(b *testing.B, enc encodingx.Encoding) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkPayload)))
+	for i := 0; i < b.N; i++ {
+		if _, err := enc.Marshal(benchmarkPayload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkAppendMarshal(b *testing.B, enc encodingx.AppendMarshaler) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkPayload)))
+	var buf []byte
+	for i := 0; i < b.N; i++ {
+		var err error
+		buf, err = enc.AppendMarshal(buf[:0], benchmarkPayload)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLazyMarshal(b *testing.B)         { benchmarkMarshal(b, encodingx.NewLazy()) }
+func BenchmarkLazyAppendMarshal(b *testing.B)   { benchmarkAppendMarshal(b, encodingx.NewLazy()) }
+func BenchmarkBase64Marshal(b *testing.B)       { benchmarkMarshal(b, encodingx.NewBase64()) }
+func BenchmarkBase64AppendMarshal(b *testing.B) { benchmarkAppendMarshal(b, encodingx.NewBase64()) }
+func BenchmarkHexTierMarshal(b *testing.B)      { benchmarkMarshal(b, encodingx.NewHexTier()) }
+func BenchmarkHexTierAppendMarshal(b *testing.B) {
+	benchmarkAppendMarshal(b, encodingx.NewHexTier())
+}
+func BenchmarkHexZlibMarshal(b *testing.B) { benchmarkMarshal(b, encodingx.NewHexZlib()) }
+func BenchmarkHexZlibAppendMarshal(b *testing.B) {
+	benchmarkAppendMarshal(b, encodingx.NewHexZlib())
+}
+
+// BenchmarkChainMarshalUnpooled 逐阶段调用 Marshal，作为池化前的对照
+func BenchmarkChainMarshalUnpooled(b *testing.B) {
+	stages := []encodingx.Encoding{encodingx.NewLazy(), encodingx.NewBase64(), encodingx.NewHex(), encodingx.NewBase64URL()}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		data := benchmarkPayload
+		for _, stage := range stages {
+			var err error
+			data, err = stage.Marshal(data)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkChainMarshal 使用池化的中间缓冲区
+func BenchmarkChainMarshal(b *testing.B) {
+	chain := encodingx.MustParseChain("Lazy|Base64|Hex|Base64URL")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := chain.Marshal(benchmarkPayload); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// Base32 系列编码器测试
+// ============================================================================
+
+// TestBase32Vectors 测试 RFC 4648 测试向量
+func TestBase32Vectors(t *testing.T) {
+	cases := []struct {
+		encoding encodingx.Encoding
+		input    string
+		expected string
+	}{
+		{encodingx.NewBase32(), "", ""},
+		{encodingx.NewBase32(), "f", "MY======"},
+		{encodingx.NewBase32(), "foobar", "MZXW6YTBOI======"},
+		{encodingx.NewBase32Hex(), "f", "CO======"},
+		{encodingx.NewBase32Hex(), "foobar", "CPNMUOJ1E8======"},
+		{encodingx.NewBase32Raw(), "f", "MY"},
+		{encodingx.NewBase32Raw(), "foobar", "MZXW6YTBOI"},
+		{encodingx.NewBase32Crockford(), "foobar", "CSQPYRK1E8"},
+	}
+	for _, tc := range cases {
+		data, err := tc.encoding.Marshal([]byte(tc.input))
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", tc.encoding, err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("%s(%q): expected %q, got %q", tc.encoding, tc.input, tc.expected, data)
+		}
+		var result encodingx.Bytes
+		if err := tc.encoding.Unmarshal(data, &result); err != nil || string(result.Data) != tc.input {
+			t.Errorf("%s round trip failed: %q, %v", tc.encoding, result.Data, err)
+		}
+	}
+}
+
+// TestBase32Registered 测试所有 Base32 编码器均已注册并可用于链
+func TestBase32Registered(t *testing.T) {
+	original := TestStruct{Integer: 32, String: "base32"}
+	for _, name := range []string{"Base32", "Base32Hex", "Base32Raw", "Base32Crockford", "Base32CrockfordCheck"} {
+		chain, err := encodingx.ParseChain("JSON|" + name)
+		if err != nil {
+			t.Fatalf("ParseChain %s failed: %v", name, err)
+		}
+		data, err := chain.Marshal(original)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", name, err)
+		}
+		var result TestStruct
+		if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+			t.Errorf("%s chain round trip failed: %v", name, err)
+		}
+	}
+}
+
+// TestBase32CrockfordTolerant 测试 Crockford 解码对大小写、I/L/O 和连字符的容错
+func TestBase32CrockfordTolerant(t *testing.T) {
+	enc := encodingx.NewBase32Crockford()
+	payload := []byte{0x08, 0x42, 0x10, 0x84, 0x21}
+	data, err := enc.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != "11111111" {
+		t.Fatalf("expected 11111111, got %s", data)
+	}
+	for _, input := range []string{"11111111", "IiLl-1111", "iiii-llll"} {
+		var result encodingx.Bytes
+		if err := enc.Unmarshal([]byte(input), &result); err != nil || !bytes.Equal(result.Data, payload) {
+			t.Errorf("decode %q failed: %x, %v", input, result.Data, err)
+		}
+	}
+
+	var zero encodingx.Bytes
+	if err := enc.Unmarshal([]byte("oOoo0000"), &zero); err != nil || !bytes.Equal(zero.Data, make([]byte, 5)) {
+		t.Errorf("decode of O as 0 failed: %x, %v", zero.Data, err)
+	}
+
+	lower := strings.ToLower("CSQPYRK1E8")
+	var result encodingx.Bytes
+	if err := enc.Unmarshal([]byte(lower), &result); err != nil || string(result.Data) != "foobar" {
+		t.Errorf("lowercase decode failed: %q, %v", result.Data, err)
+	}
+
+	for _, input := range []string{"CSQPYRK1EU", "CSQPYRK1E*", "C"} {
+		if err := enc.Unmarshal([]byte(input), &result); !errors.Is(err, encodingx.ErrBase32CrockfordInvalidData) {
+			t.Errorf("decode %q: expected ErrBase32CrockfordInvalidData, got %v", input, err)
+		}
+	}
+}
+
+// TestBase32CrockfordCheckSymbol 测试 Crockford 校验符号的生成与校验
+func TestBase32CrockfordCheckSymbol(t *testing.T) {
+	enc := encodingx.NewBase32Crockford(encodingx.WithCheckSymbol())
+	// 1234 % 37 = 13 -> 'D'
+	data, err := enc.Marshal([]byte{0x04, 0xD2})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if data[len(data)-1] != 'D' {
+		t.Errorf("expected check symbol D, got %s", data)
+	}
+
+	// 10 % 37 = 10 -> 'A'，36 % 37 -> 'U'
+	for payload, symbol := range map[byte]byte{10: 'A', 36: 'U', 35: '=', 32: '*'} {
+		data, err := enc.Marshal([]byte{payload})
+		if err != nil || data[len(data)-1] != symbol {
+			t.Errorf("payload %d: expected check symbol %c, got %s", payload, symbol, data)
+		}
+		var result encodingx.Bytes
+		lower := bytes.ToLower(data)
+		if err := enc.Unmarshal(lower, &result); err != nil || !bytes.Equal(result.Data, []byte{payload}) {
+			t.Errorf("payload %d round trip failed: %v", payload, err)
+		}
+	}
+
+	data[len(data)-1] = 'E'
+	var result encodingx.Bytes
+	if err := enc.Unmarshal(data, &result); !errors.Is(err, encodingx.ErrBase32CrockfordCheckMismatch) {
+		t.Errorf("expected ErrBase32CrockfordCheckMismatch, got %v", err)
+	}
+	if err := enc.Unmarshal(nil, &result); !errors.Is(err, encodingx.ErrBase32CrockfordInvalidData) {
+		t.Errorf("expected ErrBase32CrockfordInvalidData for missing check symbol, got %v", err)
+	}
+}
+
+// TestBase32WrongValueType 测试错误类型的输入
+func TestBase32WrongValueType(t *testing.T) {
+	cases := []struct {
+		encoding encodingx.Encoding
+		err      error
+	}{
+		{encodingx.NewBase32(), encodingx.ErrBase32WrongValueType},
+		{encodingx.NewBase32Hex(), encodingx.ErrBase32HexWrongValueType},
+		{encodingx.NewBase32Raw(), encodingx.ErrBase32RawWrongValueType},
+		{encodingx.NewBase32Crockford(), encodingx.ErrBase32CrockfordWrongValueType},
+	}
+	for _, tc := range cases {
+		if _, err := tc.encoding.Marshal(123); err != tc.err {
+			t.Errorf("%s Marshal: expected %v, got %v", tc.encoding, tc.err, err)
+		}
+		var s string
+		if err := tc.encoding.Unmarshal([]byte("MY"), &s); err != tc.err {
+			t.Errorf("%s Unmarshal: expected %v, got %v", tc.encoding, tc.err, err)
+		}
+	}
+	var result encodingx.Bytes
+	if err := encodingx.NewBase32().Unmarshal([]byte("MY"), &result); err == nil {
+		t.Error("expected error for unpadded input to Base32")
+	}
+}
+
+// TestBase32Stream 测试 Base32 流式编码与 Marshal 输出一致
+func TestBase32Stream(t *testing.T) {
+	payload := bytes.Repeat([]byte("stream base32 "), 100)
+	for _, enc := range []encodingx.StreamEncoding{encodingx.NewBase32(), encodingx.NewBase32Hex(), encodingx.NewBase32Raw()} {
+		expected, _ := enc.Marshal(payload)
+		var buf bytes.Buffer
+		encoder := enc.NewEncoder(&buf)
+		if err := encoder.Encode(payload); err != nil {
+			t.Fatalf("%s Encode failed: %v", enc, err)
+		}
+		if err := encoder.Close(); err != nil {
+			t.Fatalf("%s Close failed: %v", enc, err)
+		}
+		if !bytes.Equal(buf.Bytes(), expected) {
+			t.Errorf("%s stream output differs from Marshal", enc)
+		}
+		var result encodingx.Bytes
+		if err := enc.NewDecoder(&buf).Decode(&result); err != nil || !bytes.Equal(result.Data, payload) {
+			t.Errorf("%s stream decode failed: %v", enc, err)
+		}
+	}
+}
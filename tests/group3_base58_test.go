@@ -0,0 +1,158 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// Base58 / Base58Check 编码器测试
+// ============================================================================
+
+// TestBase58Vectors 测试 Bitcoin 字母表的已知向量和前导零处理
+func TestBase58Vectors(t *testing.T) {
+	cases := []struct {
+		input    []byte
+		expected string
+	}{
+		{nil, ""},
+		{[]byte{0}, "1"},
+		{[]byte{0, 0, 1}, "112"},
+		{[]byte{57}, "z"},
+		{[]byte{58}, "21"},
+		{[]byte("Hello World!"), "2NEpo7TZRRrLZSi2U"},
+		{[]byte("The quick brown fox jumps over the lazy dog."), "USm3fpXnKG5EUBx2ndxBDMPVciP5hGey2Jh4NDv6gmeo1LkMeiKrLJUUBk6Z"},
+	}
+	enc := encodingx.NewBase58()
+	for _, tc := range cases {
+		data, err := enc.Marshal(tc.input)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("Base58(%x): expected %q, got %q", tc.input, tc.expected, data)
+		}
+		var result encodingx.Bytes
+		if err := enc.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, tc.input) {
+			t.Errorf("Base58 round trip of %x failed: %x, %v", tc.input, result.Data, err)
+		}
+	}
+}
+
+// TestBase58RoundTrip 测试两种字母表对随机数据的往返编码
+func TestBase58RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(58))
+	for _, enc := range []encodingx.Encoding{encodingx.NewBase58(), encodingx.NewBase58Flickr(), encodingx.NewBase58Check()} {
+		for size := 0; size < 100; size++ {
+			payload := make([]byte, size)
+			rng.Read(payload)
+			if size%4 == 0 && size > 2 {
+				payload[0], payload[1] = 0, 0
+			}
+			data, err := enc.Marshal(encodingx.MakeBytes(payload))
+			if err != nil {
+				t.Fatalf("%s Marshal failed: %v", enc, err)
+			}
+			result := encodingx.NewBytes()
+			if err := enc.Unmarshal(data, result); err != nil || !bytes.Equal(result.Data, payload) {
+				t.Fatalf("%s round trip of %x failed: %x, %v", enc, payload, result.Data, err)
+			}
+		}
+	}
+
+	bitcoin, _ := encodingx.NewBase58().Marshal([]byte("flickr"))
+	flickr, _ := encodingx.NewBase58Flickr().Marshal([]byte("flickr"))
+	if bytes.Equal(bitcoin, flickr) {
+		t.Error("Bitcoin and Flickr alphabets should differ")
+	}
+}
+
+// TestBase58InvalidData 测试非法字符
+func TestBase58InvalidData(t *testing.T) {
+	var result encodingx.Bytes
+	for _, input := range []string{"0", "O", "I", "l", "abc+", "2NEpo7TZRRrLZSi2U\n"} {
+		if err := encodingx.NewBase58().Unmarshal([]byte(input), &result); !errors.Is(err, encodingx.ErrBase58InvalidData) {
+			t.Errorf("decode %q: expected ErrBase58InvalidData, got %v", input, err)
+		}
+	}
+	if _, err := encodingx.NewBase58().Marshal("string"); err != encodingx.ErrBase58WrongValueType {
+		t.Errorf("expected ErrBase58WrongValueType, got %v", err)
+	}
+	if err := encodingx.NewBase58Flickr().Unmarshal([]byte("2"), new(string)); err != encodingx.ErrBase58FlickrWrongValueType {
+		t.Errorf("expected ErrBase58FlickrWrongValueType, got %v", err)
+	}
+}
+
+// TestBase58CheckAddress 测试 Bitcoin 地址向量（版本字节 0x00）
+func TestBase58CheckAddress(t *testing.T) {
+	hash160, _ := hex.DecodeString("f54a5851e9372b87810a8e60cdd2e7cfd80b6e31")
+	enc := encodingx.NewBase58Check()
+	data, err := enc.Marshal(hash160)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != "1PMycacnJaSqwwJqjawXBErnLsZ7RkXUAs" {
+		t.Errorf("unexpected address %s", data)
+	}
+	var result encodingx.Bytes
+	if err := enc.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, hash160) {
+		t.Errorf("Unmarshal failed: %x, %v", result.Data, err)
+	}
+}
+
+// TestBase58CheckVerify 测试校验和与版本字节的验证
+func TestBase58CheckVerify(t *testing.T) {
+	enc := encodingx.NewBase58Check(encodingx.WithVersion(5))
+	data, err := enc.Marshal(bytes.Repeat([]byte{0xab}, 20))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	// P2SH 地址（版本字节 5）以 3 开头
+	if data[0] != '3' {
+		t.Errorf("version 5 should encode with leading 3, got %s", data)
+	}
+
+	var result encodingx.Bytes
+	if err := encodingx.NewBase58Check().Unmarshal(data, &result); !errors.Is(err, encodingx.ErrBase58CheckVersionMismatch) {
+		t.Errorf("expected ErrBase58CheckVersionMismatch, got %v", err)
+	}
+
+	tampered := append([]byte(nil), data...)
+	if tampered[5] == 'a' {
+		tampered[5] = 'b'
+	} else {
+		tampered[5] = 'a'
+	}
+	if err := enc.Unmarshal(tampered, &result); !errors.Is(err, encodingx.ErrBase58CheckInvalidChecksum) {
+		t.Errorf("expected ErrBase58CheckInvalidChecksum, got %v", err)
+	}
+
+	short, _ := encodingx.NewBase58().Marshal([]byte{1, 2, 3, 4})
+	if err := enc.Unmarshal(short, &result); !errors.Is(err, encodingx.ErrBase58InvalidData) {
+		t.Errorf("expected ErrBase58InvalidData, got %v", err)
+	}
+}
+
+// TestBase58Chain 测试 Base58 系列可在链中使用
+func TestBase58Chain(t *testing.T) {
+	original := TestStruct{Integer: 58, String: "chain"}
+	for _, name := range []string{"Base58", "Base58Flickr", "Base58Check"} {
+		chain, err := encodingx.ParseChain("JSON|" + name)
+		if err != nil {
+			t.Fatalf("ParseChain %s failed: %v", name, err)
+		}
+		data, err := chain.Marshal(original)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", name, err)
+		}
+		var result TestStruct
+		if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+			t.Errorf("%s chain round trip failed: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// Base64 无填充、MIME 与宽松解码测试
+// ============================================================================
+
+// TestBase64RawVariants 测试无填充变体的输出与往返
+func TestBase64RawVariants(t *testing.T) {
+	payload := []byte{0xfb, 0xff, 0xbf, 0x01}
+	cases := []struct {
+		encoding encodingx.Encoding
+		expected string
+	}{
+		{encodingx.NewBase64Raw(), "+/+/AQ"},
+		{encodingx.NewBase64URLRaw(), "-_-_AQ"},
+	}
+	for _, tc := range cases {
+		data, err := tc.encoding.Marshal(payload)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", tc.encoding, err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("%s: expected %q, got %q", tc.encoding, tc.expected, data)
+		}
+		var result encodingx.Bytes
+		if err := tc.encoding.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, payload) {
+			t.Errorf("%s round trip failed: %v", tc.encoding, err)
+		}
+		// 严格模式拒绝带填充的输入
+		if err := tc.encoding.Unmarshal(append(data, "=="...), &result); err == nil {
+			t.Errorf("%s should reject padded input", tc.encoding)
+		}
+	}
+	if _, err := encodingx.NewBase64Raw().Marshal(1); err != encodingx.ErrBase64RawWrongValueType {
+		t.Errorf("expected ErrBase64RawWrongValueType, got %v", err)
+	}
+	if err := encodingx.NewBase64URLRaw().Unmarshal(nil, new(string)); err != encodingx.ErrBase64URLRawWrongValueType {
+		t.Errorf("expected ErrBase64URLRawWrongValueType, got %v", err)
+	}
+}
+
+// TestBase64MIME 测试 MIME 变体按 76 列换行
+func TestBase64MIME(t *testing.T) {
+	payload := bytes.Repeat([]byte("mime payload "), 20)
+	enc := encodingx.NewBase64MIME()
+	data, err := enc.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	lines := strings.Split(string(data), "\r\n")
+	for i, line := range lines {
+		if i < len(lines)-1 && len(line) != 76 {
+			t.Errorf("line %d has %d columns", i, len(line))
+		}
+		if len(line) > 76 {
+			t.Errorf("line %d exceeds 76 columns", i)
+		}
+	}
+	if strings.Join(lines, "") != base64.StdEncoding.EncodeToString(payload) {
+		t.Error("MIME output does not match standard base64")
+	}
+
+	var result encodingx.Bytes
+	if err := enc.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, payload) {
+		t.Errorf("round trip failed: %v", err)
+	}
+	if name := encodingx.NewBase64MIME(encodingx.WithName("acme.MIME")).String(); name != "acme.MIME" {
+		t.Errorf("got name %q, want acme.MIME", name)
+	}
+
+	// 流式输出与 Marshal 一致
+	var buf bytes.Buffer
+	encoder := enc.NewEncoder(&buf)
+	for _, chunk := range [][]byte{payload[:7], payload[7:100], payload[100:]} {
+		if err := encoder.Encode(chunk); err != nil {
+			t.Fatalf("Encode failed: %v", err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Errorf("stream output differs from Marshal:\n%s\n%s", buf.Bytes(), data)
+	}
+	if err := enc.NewDecoder(&buf).Decode(&result); err != nil || !bytes.Equal(result.Data, payload) {
+		t.Errorf("stream decode failed: %v", err)
+	}
+
+	short, _ := enc.Marshal([]byte("short"))
+	if string(short) != "c2hvcnQ=" {
+		t.Errorf("short payload should fit on one line, got %q", short)
+	}
+}
+
+// TestBase64Lenient 测试宽松模式接受有无填充及空白字符
+func TestBase64Lenient(t *testing.T) {
+	payload := []byte("lenient decoding?>")
+	padded := base64.StdEncoding.EncodeToString(payload[:17])
+	inputs := []string{
+		padded,
+		strings.TrimRight(padded, "="),
+		" " + padded[:10] + "\r\n" + padded[10:] + "\n",
+		padded[:4] + "\t" + strings.TrimRight(padded[4:], "="),
+	}
+	enc, err := encodingx.Lookup("Base64Lenient")
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	var result encodingx.Bytes
+	for _, input := range inputs {
+		if err := enc.Unmarshal([]byte(input), &result); err != nil || !bytes.Equal(result.Data, payload[:17]) {
+			t.Errorf("decode %q failed: %q, %v", input, result.Data, err)
+		}
+	}
+	// 宽松模式的输出与严格模式一致
+	data, _ := enc.Marshal(payload)
+	expected, _ := encodingx.NewBase64().Marshal(payload)
+	if !bytes.Equal(data, expected) {
+		t.Error("lenient output should be padded base64")
+	}
+
+	if err := encodingx.NewBase64().Unmarshal([]byte(inputs[1]), &result); err == nil {
+		t.Error("strict Base64 should reject unpadded input")
+	}
+
+	urlEnc := encodingx.NewBase64URL(encodingx.WithLenient())
+	urlInput := base64.RawURLEncoding.EncodeToString(payload)
+	if err := urlEnc.Unmarshal([]byte(urlInput[:8]+"\n"+urlInput[8:]), &result); err != nil || !bytes.Equal(result.Data, payload) {
+		t.Errorf("lenient Base64URL decode failed: %v", err)
+	}
+	urlPadded := base64.URLEncoding.EncodeToString(payload[:17])
+	if err := urlEnc.NewDecoder(strings.NewReader(" " + urlPadded + "\n")).Decode(&result); err != nil || !bytes.Equal(result.Data, payload[:17]) {
+		t.Errorf("lenient Base64URL stream decode failed: %v", err)
+	}
+
+	rawEnc := encodingx.NewBase64URLRaw(encodingx.WithLenient())
+	if err := rawEnc.Unmarshal([]byte(base64.URLEncoding.EncodeToString(payload)), &result); err != nil || !bytes.Equal(result.Data, payload) {
+		t.Errorf("lenient Base64URLRaw should accept padded input: %v", err)
+	}
+	if err := rawEnc.Unmarshal([]byte("a!b"), &result); err == nil {
+		t.Error("lenient mode should still reject invalid characters")
+	}
+
+	// 填充只能出现在末尾，且长度须与数据相符
+	stream := encodingx.NewBase64(encodingx.WithLenient())
+	invalid := []string{"YQ==YQ==", "YQ==YQ", "Y=Q", "YQ= =YQ", "=YQ", "QQ=====", "QQQ==", "QQ=", "QUJD=", "Q==="}
+	for _, input := range invalid {
+		if err := enc.Unmarshal([]byte(input), &result); err == nil {
+			t.Errorf("decode %q: expected error, got %q", input, result.Data)
+		}
+		if err := stream.NewDecoder(strings.NewReader(input)).Decode(&result); err == nil {
+			t.Errorf("stream decode %q: expected error, got %q", input, result.Data)
+		}
+	}
+	if err := stream.NewDecoder(strings.NewReader("YQ= =\n")).Decode(&result); err != nil || string(result.Data) != "a" {
+		t.Errorf("stream decode with trailing padding failed: %q, %v", result.Data, err)
+	}
+	if err := stream.NewDecoder(strings.NewReader("QUI=")).Decode(&result); err != nil || string(result.Data) != "AB" {
+		t.Errorf("stream decode with one padding character failed: %q, %v", result.Data, err)
+	}
+}
+
+// TestBase64VariantsChain 测试新变体已注册并可用于链
+func TestBase64VariantsChain(t *testing.T) {
+	original := TestStruct{Integer: 64, String: strings.Repeat("chain ", 30)}
+	for _, name := range []string{"Base64Raw", "Base64URLRaw", "Base64MIME", "Base64Lenient", "Base64URLLenient"} {
+		chain, err := encodingx.ParseChain("JSON|" + name)
+		if err != nil {
+			t.Fatalf("ParseChain %s failed: %v", name, err)
+		}
+		data, err := chain.Marshal(original)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", name, err)
+		}
+		var result TestStruct
+		if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+			t.Errorf("%s chain round trip failed: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// Ascii85 / Z85 / Base85 编码器测试
+// ============================================================================
+
+// TestBase85Vectors 测试三种 base85 变体的已知向量
+func TestBase85Vectors(t *testing.T) {
+	cases := []struct {
+		encoding encodingx.Encoding
+		input    []byte
+		expected string
+	}{
+		{encodingx.NewAscii85(), []byte("Man "), "<~9jqo^~>"},
+		{encodingx.NewAscii85(), []byte("sure."), "<~F*2M7/c~>"},
+		{encodingx.NewAscii85(), make([]byte, 4), "<~z~>"},
+		{encodingx.NewAscii85(), nil, "<~~>"},
+		{encodingx.NewZ85(), []byte{0x86, 0x4F, 0xD2, 0x6F, 0xB5, 0x59, 0xF7, 0x5B}, "HelloWorld"},
+		{encodingx.NewZ85(encodingx.WithPadding()), []byte("Hello"), "nm=QNzVx+q3"},
+		{encodingx.NewBase85(), []byte("hello"), "Xk~0{Zv"},
+		{encodingx.NewBase85(), []byte{0, 1, 2}, "009C"},
+	}
+	for _, tc := range cases {
+		data, err := tc.encoding.Marshal(tc.input)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", tc.encoding, err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("%s(%q): expected %q, got %q", tc.encoding, tc.input, tc.expected, data)
+		}
+		var result encodingx.Bytes
+		if err := tc.encoding.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, tc.input) {
+			t.Errorf("%s round trip of %q failed: %q, %v", tc.encoding, tc.input, result.Data, err)
+		}
+	}
+}
+
+// TestBase85RoundTrip 测试随机数据在各种长度下的往返编码
+func TestBase85RoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(85))
+	for _, name := range []string{"Ascii85", "Z85Padded", "Base85"} {
+		enc, err := encodingx.Lookup(name)
+		if err != nil {
+			t.Fatalf("Lookup %s failed: %v", name, err)
+		}
+		for size := 0; size < 64; size++ {
+			payload := make([]byte, size)
+			rng.Read(payload)
+			if size%3 == 0 {
+				for i := 0; i < size/2; i++ {
+					payload[i] = 0
+				}
+			}
+			data, err := enc.Marshal(payload)
+			if err != nil {
+				t.Fatalf("%s Marshal failed: %v", name, err)
+			}
+			var result encodingx.Bytes
+			if err := enc.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, payload) {
+				t.Fatalf("%s round trip of %x failed: %x, %v", name, payload, result.Data, err)
+			}
+		}
+	}
+}
+
+// TestAscii85Framing 测试 Ascii85 定界符与空白处理
+func TestAscii85Framing(t *testing.T) {
+	enc := encodingx.NewAscii85()
+	var result encodingx.Bytes
+	for _, input := range []string{"<~9jqo^~>", "9jqo^~>", "  <~9j\nqo ^~>\r\n"} {
+		if err := enc.Unmarshal([]byte(input), &result); err != nil || string(result.Data) != "Man " {
+			t.Errorf("decode %q failed: %q, %v", input, result.Data, err)
+		}
+	}
+	for _, input := range []string{"<~9jqo^", "", "~"} {
+		if err := enc.Unmarshal([]byte(input), &result); !errors.Is(err, encodingx.ErrAscii85InvalidFrame) {
+			t.Errorf("decode %q: expected ErrAscii85InvalidFrame, got %v", input, err)
+		}
+	}
+}
+
+// TestBase85Malformed 测试非法输入返回类型化错误而不是 panic
+func TestBase85Malformed(t *testing.T) {
+	cases := []struct {
+		encoding encodingx.Encoding
+		input    string
+		err      error
+	}{
+		{encodingx.NewAscii85(), "<~a~>", encodingx.ErrAscii85InvalidData},
+		{encodingx.NewAscii85(), "<~uuuuu~>", encodingx.ErrAscii85InvalidData},
+		{encodingx.NewAscii85(), "<~s8W-\"~>", encodingx.ErrAscii85InvalidData},
+		{encodingx.NewAscii85(), "<~ab z~>", encodingx.ErrAscii85InvalidData},
+		{encodingx.NewAscii85(), "<~abc{~>", encodingx.ErrAscii85InvalidData},
+		{encodingx.NewZ85(), "Hello~", encodingx.ErrZ85InvalidData},
+		{encodingx.NewZ85(), "Hell", encodingx.ErrZ85InvalidData},
+		{encodingx.NewZ85(), "Hell~", encodingx.ErrZ85InvalidData},
+		{encodingx.NewZ85(), "#####", encodingx.ErrZ85InvalidData},
+		{encodingx.NewZ85(encodingx.WithPadding()), "HelloWorld", encodingx.ErrZ85InvalidData},
+		{encodingx.NewZ85(encodingx.WithPadding()), "HelloWorld4", encodingx.ErrZ85InvalidData},
+		{encodingx.NewZ85(encodingx.WithPadding()), "1", encodingx.ErrZ85InvalidData},
+		{encodingx.NewBase85(), "X", encodingx.ErrBase85InvalidData},
+		{encodingx.NewBase85(), "|NsC1", encodingx.ErrBase85InvalidData},
+		{encodingx.NewBase85(), "Xk\"0", encodingx.ErrBase85InvalidData},
+	}
+	for _, tc := range cases {
+		var result encodingx.Bytes
+		if err := tc.encoding.Unmarshal([]byte(tc.input), &result); !errors.Is(err, tc.err) {
+			t.Errorf("%s decode %q: expected %v, got %v", tc.encoding, tc.input, tc.err, err)
+		}
+	}
+
+	if _, err := encodingx.NewZ85().Marshal([]byte("abc")); !errors.Is(err, encodingx.ErrZ85Unaligned) {
+		t.Errorf("expected ErrZ85Unaligned, got %v", err)
+	}
+
+	wrongTypes := map[encodingx.Encoding]error{
+		encodingx.NewAscii85(): encodingx.ErrAscii85WrongValueType,
+		encodingx.NewZ85():     encodingx.ErrZ85WrongValueType,
+		encodingx.NewBase85():  encodingx.ErrBase85WrongValueType,
+	}
+	for enc, expected := range wrongTypes {
+		if _, err := enc.Marshal(1); err != expected {
+			t.Errorf("%s Marshal: expected %v, got %v", enc, expected, err)
+		}
+		if err := enc.Unmarshal(nil, new(int)); err != expected {
+			t.Errorf("%s Unmarshal: expected %v, got %v", enc, expected, err)
+		}
+	}
+}
+
+// TestBase85FuzzNoPanic 测试随机输入不会导致 panic
+func TestBase85FuzzNoPanic(t *testing.T) {
+	rng := rand.New(rand.NewSource(1924))
+	encodings := []encodingx.Encoding{
+		encodingx.NewAscii85(),
+		encodingx.NewZ85(),
+		encodingx.NewZ85(encodingx.WithPadding()),
+		encodingx.NewBase85(),
+	}
+	for i := 0; i < 2000; i++ {
+		input := make([]byte, rng.Intn(24))
+		rng.Read(input)
+		if i%2 == 0 {
+			input = append([]byte("<~"), append(input, "~>"...)...)
+		}
+		for _, enc := range encodings {
+			var result encodingx.Bytes
+			_ = enc.Unmarshal(input, &result)
+		}
+	}
+}
+
+// TestBase85Chain 测试 base85 系列可在链中使用
+func TestBase85Chain(t *testing.T) {
+	original := TestStruct{Integer: 85, String: "chain"}
+	for _, name := range []string{"Ascii85", "Z85Padded", "Base85"} {
+		chain, err := encodingx.ParseChain("JSON|" + name)
+		if err != nil {
+			t.Fatalf("ParseChain %s failed: %v", name, err)
+		}
+		data, err := chain.Marshal(original)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", name, err)
+		}
+		var result TestStruct
+		if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+			t.Errorf("%s chain round trip failed: %v", name, err)
+		}
+	}
+}
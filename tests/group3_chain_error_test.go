@@ -0,0 +1,88 @@
+package encodingx_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// ChainError 阶段错误测试
+// ============================================================================
+
+// TestChainErrorMarshalStage 测试序列化失败时报告阶段、名称和方向
+func TestChainErrorMarshalStage(t *testing.T) {
+	chain := encodingx.NewChainEncoding([]string{"Base64", "Hex"}, []string{"Hex", "Base64"})
+	_, err := chain.Marshal(TestStruct{})
+
+	var chainErr *encodingx.ChainError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("expected *ChainError, got %T", err)
+	}
+	if chainErr.Stage != 0 || chainErr.Name != "Base64" || chainErr.Direction != encodingx.ChainMarshal {
+		t.Errorf("unexpected stage info %+v", chainErr)
+	}
+	if !errors.Is(err, encodingx.ErrBase64WrongValueType) {
+		t.Errorf("errors.Is should see ErrBase64WrongValueType, got %v", err)
+	}
+	expected := `encoding chain marshal stage 0 "Base64": encoding base64 converts on wrong type value`
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
+
+// TestChainErrorUnmarshalStage 测试反序列化失败时报告阶段，并保留底层错误类型
+func TestChainErrorUnmarshalStage(t *testing.T) {
+	chain := encodingx.NewChainEncoding(
+		[]string{"JSON", "Base64", "Hex"},
+		[]string{"Hex", "Base64", "JSON"},
+	)
+
+	// Hex 合法，但 Base64 非法
+	var result TestStruct
+	err := chain.Unmarshal([]byte("21212121"), &result)
+	var chainErr *encodingx.ChainError
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("expected *ChainError, got %T", err)
+	}
+	if chainErr.Stage != 1 || chainErr.Name != "Base64" || chainErr.Direction != encodingx.ChainUnmarshal {
+		t.Errorf("unexpected stage info %+v", chainErr)
+	}
+	var corrupt base64.CorruptInputError
+	if !errors.As(err, &corrupt) {
+		t.Errorf("errors.As should reach base64.CorruptInputError, got %v", err)
+	}
+
+	// 最后一个解码阶段失败
+	data, err := encodingx.NewChainEncoding([]string{"Lazy", "Base64", "Hex"}, nil).Marshal([]byte("{bad json"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	err = chain.Unmarshal(data, &result)
+	if !errors.As(err, &chainErr) {
+		t.Fatalf("expected *ChainError, got %T", err)
+	}
+	if chainErr.Stage != 2 || chainErr.Name != "JSON" {
+		t.Errorf("unexpected stage info %+v", chainErr)
+	}
+	var syntax *json.SyntaxError
+	if !errors.As(err, &syntax) {
+		t.Errorf("errors.As should reach *json.SyntaxError, got %v", err)
+	}
+}
+
+// TestChainDirectionString 测试方向的字符串表示
+func TestChainDirectionString(t *testing.T) {
+	if encodingx.ChainMarshal.String() != "marshal" {
+		t.Errorf("unexpected %s", encodingx.ChainMarshal)
+	}
+	if encodingx.ChainUnmarshal.String() != "unmarshal" {
+		t.Errorf("unexpected %s", encodingx.ChainUnmarshal)
+	}
+	if encodingx.ChainDirection(9).String() != "chainDirectionName=9?" {
+		t.Errorf("unexpected %s", encodingx.ChainDirection(9))
+	}
+}
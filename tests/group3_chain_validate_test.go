@@ -0,0 +1,76 @@
+package encodingx_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// ChainEncoding 构造期校验测试
+// ============================================================================
+
+// TestChainEncodingValidate 测试 Validate 检查名称、Style 规则和镜像关系
+func TestChainEncodingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoder []string
+		decoder []string
+		err     error
+		stage   string
+	}{
+		{"valid", []string{"JSON", "Base64"}, []string{"Base64", "JSON"}, nil, ""},
+		{"valid bytes only", []string{"Lazy", "Hex"}, []string{"Hex", "Lazy"}, nil, ""},
+		{"empty", []string{}, []string{}, nil, ""},
+		{"missing encoder", []string{"JSON", "Nope"}, []string{"Nope", "JSON"}, encodingx.ErrEncodingMissingEncoding, `marshal stage 1 "Nope"`},
+		{"missing decoder", []string{"JSON"}, []string{"Nope"}, encodingx.ErrEncodingMissingEncoding, `unmarshal stage 0 "Nope"`},
+		{"struct middle encoder", []string{"JSON", "YAML"}, []string{"YAML", "JSON"}, encodingx.ErrWrongEncodingStyle, `marshal stage 1 "YAML"`},
+		{"struct middle decoder", []string{"JSON", "Base64"}, []string{"XML", "JSON"}, encodingx.ErrWrongEncodingStyle, `unmarshal stage 0 "XML"`},
+		{"length mismatch", []string{"JSON", "Base64"}, []string{"JSON"}, encodingx.ErrChainNotMirrored, "2 encoder stages, 1 decoder stages"},
+		{"not mirrored", []string{"JSON", "Base64"}, []string{"Hex", "JSON"}, encodingx.ErrChainNotMirrored, `unmarshal stage 0 "Hex": encoding chain decoder does not mirror encoder, expected "Base64"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := encodingx.NewChainEncoding(tt.encoder, tt.decoder).Validate()
+			if tt.err == nil {
+				if err != nil {
+					t.Errorf("expected valid chain, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if !strings.Contains(err.Error(), tt.stage) {
+				t.Errorf("error %q should name the stage %q", err.Error(), tt.stage)
+			}
+		})
+	}
+}
+
+// TestNewChainEncodingChecked 测试带校验的构造函数
+func TestNewChainEncodingChecked(t *testing.T) {
+	chain, err := encodingx.NewChainEncodingChecked([]string{"JSON", "Base64"}, []string{"Base64", "JSON"})
+	if err != nil {
+		t.Fatalf("NewChainEncodingChecked failed: %v", err)
+	}
+	if chain == nil {
+		t.Fatal("NewChainEncodingChecked should return a chain")
+	}
+
+	chain, err = encodingx.NewChainEncodingChecked([]string{"JSON", "JSON"}, []string{"JSON", "JSON"})
+	if !errors.Is(err, encodingx.ErrWrongEncodingStyle) {
+		t.Errorf("expected ErrWrongEncodingStyle, got %v", err)
+	}
+	if chain != nil {
+		t.Error("NewChainEncodingChecked should return nil chain on error")
+	}
+
+	registry := encodingx.NewRegistry()
+	registry.MustRegister(encodingx.NewJSON())
+	if _, err := registry.NewChainEncodingChecked([]string{"JSON", "Base64"}, []string{"Base64", "JSON"}); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected ErrEncodingMissingEncoding, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package encodingx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// 编码链描述字符串解析与序列化测试
+// ============================================================================
+
+// TestParseChainStringForm 测试解析 String() 输出格式
+func TestParseChainStringForm(t *testing.T) {
+	specs := []string{
+		"[JSON:Base64] -> [Base64:JSON]",
+		"[JSON:Base64:Lazy] -> [Lazy:Base64:JSON]",
+		"[JSON] -> [JSON]",
+		"[] -> []",
+	}
+	for _, spec := range specs {
+		chain, err := encodingx.ParseChain(spec)
+		if err != nil {
+			t.Errorf("ParseChain(%q) failed: %v", spec, err)
+			continue
+		}
+		if chain.String() != spec {
+			t.Errorf("ParseChain(%q).String() = %q", spec, chain.String())
+		}
+	}
+
+	// 容忍额外空白
+	chain, err := encodingx.ParseChain("  [ JSON : Base64 ]->[Base64:JSON]  ")
+	if err != nil {
+		t.Fatalf("ParseChain with extra whitespace failed: %v", err)
+	}
+	if chain.String() != "[JSON:Base64] -> [Base64:JSON]" {
+		t.Errorf("unexpected chain %s", chain.String())
+	}
+}
+
+// TestParseChainPipelineForm 测试解析管道格式，解码顺序为编码顺序的逆序
+func TestParseChainPipelineForm(t *testing.T) {
+	chain, err := encodingx.ParseChain("JSON | Base64|HexTier")
+	if err != nil {
+		t.Fatalf("ParseChain failed: %v", err)
+	}
+	expected := "[JSON:Base64:HexTier] -> [HexTier:Base64:JSON]"
+	if chain.String() != expected {
+		t.Errorf("expected %q, got %q", expected, chain.String())
+	}
+
+	original := TestStruct{Integer: 7, String: "pipe", Bool: true, Float: 1.5}
+	data, err := chain.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result TestStruct
+	if err := chain.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !original.Equal(result) {
+		t.Errorf("round trip failed: %+v != %+v", result, original)
+	}
+}
+
+// TestParseChainErrors 测试错误报告包含出错位置
+func TestParseChainErrors(t *testing.T) {
+	tests := []struct {
+		spec  string
+		err   error
+		pos   int
+		token string
+	}{
+		{"", encodingx.ErrChainSpecSyntax, 0, ""},
+		{"JSON|Nope", encodingx.ErrEncodingMissingEncoding, 5, "Nope"},
+		{"[JSON:Nope] -> [JSON]", encodingx.ErrEncodingMissingEncoding, 6, "Nope"},
+		{"[JSON] -> [Base64:Nope]", encodingx.ErrEncodingMissingEncoding, 18, "Nope"},
+		{"JSON||Base64", encodingx.ErrChainSpecSyntax, 5, "|Base64"},
+		{"JSON:Base64", encodingx.ErrChainSpecSyntax, 4, ":Base64"},
+		{"[JSON] [JSON]", encodingx.ErrChainSpecSyntax, 7, "[JSON]"},
+		{"[JSON -> [JSON]", encodingx.ErrChainSpecSyntax, 6, "->"},
+		{"JSON|1Base64", encodingx.ErrEncodingInvalidName, 5, "1Base64"},
+		{"[JSON] -> [JSON] x", encodingx.ErrChainSpecSyntax, 17, "x"},
+	}
+	for _, tt := range tests {
+		_, err := encodingx.ParseChain(tt.spec)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("ParseChain(%q): expected %v, got %v", tt.spec, tt.err, err)
+			continue
+		}
+		var specErr *encodingx.ChainSpecError
+		if !errors.As(err, &specErr) {
+			t.Errorf("ParseChain(%q): expected *ChainSpecError, got %T", tt.spec, err)
+			continue
+		}
+		if specErr.Pos != tt.pos || specErr.Token != tt.token {
+			t.Errorf("ParseChain(%q): expected position %d token %q, got %d %q",
+				tt.spec, tt.pos, tt.token, specErr.Pos, specErr.Token)
+		}
+	}
+}
+
+// TestParseChainRegistry 测试在指定注册表中解析
+func TestParseChainRegistry(t *testing.T) {
+	registry := encodingx.NewRegistry()
+	registry.MustRegister(encodingx.NewBase64())
+	if _, err := registry.ParseChain("Base64"); err != nil {
+		t.Errorf("ParseChain in registry failed: %v", err)
+	}
+	if _, err := registry.ParseChain("JSON|Base64"); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected ErrEncodingMissingEncoding, got %v", err)
+	}
+}
+
+// TestMustParseChainPanics 测试 MustParseChain 在解析失败时 panic
+func TestMustParseChainPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParseChain should panic on invalid spec")
+		}
+	}()
+	encodingx.MustParseChain("[JSON")
+}
+
+// TestChainEncodingTextRoundTrip 测试 MarshalText/UnmarshalText 可用于配置文件
+func TestChainEncodingTextRoundTrip(t *testing.T) {
+	mirrored := encodingx.NewChainEncoding([]string{"JSON", "Base64"}, []string{"Base64", "JSON"})
+	text, err := mirrored.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText failed: %v", err)
+	}
+	if string(text) != "JSON|Base64" {
+		t.Errorf("mirrored chain should use pipeline form, got %q", text)
+	}
+
+	asymmetric := encodingx.NewChainEncoding([]string{"JSON", "Base64"}, []string{"Base64", "YAML"})
+	text, err = asymmetric.MarshalText()
+	if err != nil {
+		t.Fatalf("MarshalText failed: %v", err)
+	}
+	if string(text) != asymmetric.String() {
+		t.Errorf("asymmetric chain should use String form, got %q", text)
+	}
+
+	type config struct {
+		Chain encodingx.ChainEncoding `json:"chain"`
+	}
+	data, err := json.Marshal(config{Chain: *asymmetric})
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+	var decoded config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	if decoded.Chain.String() != asymmetric.String() {
+		t.Errorf("expected %q, got %q", asymmetric.String(), decoded.Chain.String())
+	}
+	if err := json.Unmarshal([]byte(`{"chain":"JSON|Nope"}`), &decoded); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected ErrEncodingMissingEncoding, got %v", err)
+	}
+}
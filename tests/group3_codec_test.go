@@ -0,0 +1,142 @@
+package encodingx_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// 泛型 Codec[T] 测试
+// ============================================================================
+
+// TestCodecRoundTrip 测试 Codec 在不同编码器上的类型安全往返
+func TestCodecRoundTrip(t *testing.T) {
+	original := TestStruct{Integer: 1, String: "codec", Bool: true, Float: 1.25}
+	encodings := []encodingx.Encoding{
+		encodingx.NewJSON(),
+		encodingx.NewYAML(),
+		encodingx.NewXML(),
+		encodingx.NewMsgPack(),
+		encodingx.MustParseChain("JSON|Base64|HexZlib"),
+	}
+	for _, enc := range encodings {
+		t.Run(enc.String(), func(t *testing.T) {
+			codec := encodingx.NewCodec[TestStruct](enc)
+			data, err := codec.Marshal(original)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			result, err := codec.Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !original.Equal(result) {
+				t.Errorf("round trip failed: %+v", result)
+			}
+		})
+	}
+}
+
+// TestCodecPointerType 测试指针类型参数会分配新值
+func TestCodecPointerType(t *testing.T) {
+	codec := encodingx.NewCodec[*TestStruct](encodingx.NewJSON())
+	original := &TestStruct{Integer: 5, String: "ptr"}
+	result, err := codec.Unmarshal(codec.MustMarshal(original))
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if result == nil || !original.Equal(*result) {
+		t.Errorf("pointer round trip failed: %+v", result)
+	}
+}
+
+// TestCodecBytes 测试 Codec 与字节编码器配合使用
+func TestCodecBytes(t *testing.T) {
+	codec := encodingx.NewCodec[encodingx.Bytes](encodingx.NewBase64())
+	data := codec.MustMarshal(encodingx.MakeBytes("hello"))
+	if string(data) != "aGVsbG8=" {
+		t.Errorf("unexpected output %s", data)
+	}
+	if result := codec.MustUnmarshal(data); string(result.Data) != "hello" {
+		t.Errorf("unexpected result %s", result.Data)
+	}
+}
+
+// TestCodecErrors 测试错误传递和 Must 系列函数 panic
+func TestCodecErrors(t *testing.T) {
+	codec := encodingx.NewCodec[TestStruct](encodingx.NewJSON())
+	result, err := codec.Unmarshal([]byte("{bad"))
+	if err == nil {
+		t.Error("Unmarshal should fail on invalid JSON")
+	}
+	if result != (TestStruct{}) {
+		t.Errorf("failed Unmarshal should return zero value, got %+v", result)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustUnmarshal should panic on invalid data")
+		}
+	}()
+	codec.MustUnmarshal([]byte("{bad"))
+}
+
+// TestCodecSliceAndMap 测试切片和映射辅助函数
+func TestCodecSliceAndMap(t *testing.T) {
+	codec := encodingx.NewCodec[TestStruct](encodingx.NewJSON())
+	items := []TestStruct{{Integer: 1}, {Integer: 2, String: "two"}}
+	data, err := codec.MarshalSlice(items)
+	if err != nil {
+		t.Fatalf("MarshalSlice failed: %v", err)
+	}
+	decoded, err := codec.UnmarshalSlice(data)
+	if err != nil {
+		t.Fatalf("UnmarshalSlice failed: %v", err)
+	}
+	if len(decoded) != 2 || !decoded[1].Equal(items[1]) {
+		t.Errorf("unexpected slice %+v", decoded)
+	}
+
+	csvCodec := encodingx.NewCodec[*CSVRecord](encodingx.NewCSVWithHeaders())
+	records := []*CSVRecord{{ID: 1, Name: "a", Value: 0.5}}
+	data, err = csvCodec.MarshalSlice(records)
+	if err != nil {
+		t.Fatalf("CSV MarshalSlice failed: %v", err)
+	}
+	decodedRecords, err := csvCodec.UnmarshalSlice(data)
+	if err != nil {
+		t.Fatalf("CSV UnmarshalSlice failed: %v", err)
+	}
+	if !CSVRecordsEqual(decodedRecords, records) {
+		t.Errorf("unexpected records %+v", decodedRecords)
+	}
+
+	m := map[string]TestStruct{"a": {Integer: 1}, "b": {Bool: true}}
+	data, err = encodingx.MarshalMap(codec, m)
+	if err != nil {
+		t.Fatalf("MarshalMap failed: %v", err)
+	}
+	decodedMap, err := encodingx.UnmarshalMap[string](codec, data)
+	if err != nil {
+		t.Fatalf("UnmarshalMap failed: %v", err)
+	}
+	if len(decodedMap) != 2 || !decodedMap["b"].Equal(m["b"]) {
+		t.Errorf("unexpected map %+v", decodedMap)
+	}
+}
+
+// TestLookupCodec 测试从注册表创建 Codec
+func TestLookupCodec(t *testing.T) {
+	codec, err := encodingx.LookupCodec[TestStruct]("YAML")
+	if err != nil {
+		t.Fatalf("LookupCodec failed: %v", err)
+	}
+	if codec.Encoding().String() != "YAML" {
+		t.Errorf("unexpected encoding %s", codec.Encoding())
+	}
+	if _, err := encodingx.LookupCodec[TestStruct]("Nope"); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected ErrEncodingMissingEncoding, got %v", err)
+	}
+}
@@ -0,0 +1,294 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/aura-studio/encodingx"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// ============================================================================
+// Zstd / LZ4 / LZ4Block / Snappy / SnappyFramed / Brotli 压缩编码器测试
+// ============================================================================
+
+var compressCodecs = []encodingx.Encoding{
+	encodingx.NewZstd(),
+	encodingx.NewLZ4(),
+	encodingx.NewLZ4Block(),
+	encodingx.NewSnappy(),
+	encodingx.NewSnappyFramed(),
+	encodingx.NewBrotli(),
+}
+
+// TestCompressCodecsRoundTrip 测试可压缩、不可压缩和空数据的往返
+func TestCompressCodecsRoundTrip(t *testing.T) {
+	random := make([]byte, 4096)
+	rand.Read(random)
+	inputs := map[string][]byte{
+		"compressible":   compressPayload,
+		"incompressible": random,
+		"empty":          {},
+		"single":         []byte("x"),
+	}
+	for _, enc := range compressCodecs {
+		t.Run(enc.String(), func(t *testing.T) {
+			for name, input := range inputs {
+				data, err := enc.Marshal(input)
+				if err != nil {
+					t.Fatalf("%s: Marshal failed: %v", name, err)
+				}
+				if name == "compressible" && len(data) >= len(input)/4 {
+					t.Errorf("payload not compressed: %d bytes", len(data))
+				}
+				var result encodingx.Bytes
+				if err := enc.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, input) {
+					t.Errorf("%s: round trip failed: %v", name, err)
+				}
+			}
+
+			if _, err := enc.Marshal(1); err == nil {
+				t.Error("expected error for wrong value type")
+			}
+			data, _ := enc.Marshal(compressPayload)
+			if err := enc.Unmarshal(data, new(string)); err == nil {
+				t.Error("expected error for wrong target type")
+			}
+			var result encodingx.Bytes
+			if err := enc.Unmarshal(data[:len(data)/2], &result); err == nil {
+				t.Error("expected error for truncated data")
+			}
+		})
+	}
+}
+
+// TestCompressCodecsStream 测试流式输出与 Marshal 一致
+func TestCompressCodecsStream(t *testing.T) {
+	for _, enc := range compressCodecs {
+		streamEnc, ok := enc.(encodingx.StreamEncoding)
+		if !ok {
+			continue
+		}
+		t.Run(enc.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := streamEnc.NewEncoder(&buf)
+			w := encoder.(io.Writer)
+			for i := 0; i < len(compressPayload); i += 100 {
+				if _, err := w.Write(compressPayload[i:min(i+100, len(compressPayload))]); err != nil {
+					t.Fatalf("Write failed: %v", err)
+				}
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+			var result encodingx.Bytes
+			if err := enc.Unmarshal(buf.Bytes(), &result); err != nil || !bytes.Equal(result.Data, compressPayload) {
+				t.Errorf("Unmarshal of stream output failed: %v", err)
+			}
+
+			data, _ := enc.Marshal(compressPayload)
+			decoded, err := io.ReadAll(streamEnc.NewDecoder(bytes.NewReader(data)).(io.Reader))
+			if err != nil || !bytes.Equal(decoded, compressPayload) {
+				t.Errorf("stream decode failed: %v", err)
+			}
+		})
+	}
+}
+
+// TestCompressCodecsInterop 测试输出为各自的标准格式
+func TestCompressCodecsInterop(t *testing.T) {
+	data, _ := encodingx.NewZstd().Marshal(compressPayload)
+	zr, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatalf("zstd.NewReader failed: %v", err)
+	}
+	defer zr.Close()
+	if decoded, err := zr.DecodeAll(data, nil); err != nil || !bytes.Equal(decoded, compressPayload) {
+		t.Errorf("zstd interop failed: %v", err)
+	}
+	if empty, _ := encodingx.NewZstd().Marshal([]byte{}); !bytes.HasPrefix(empty, []byte{0x28, 0xb5, 0x2f, 0xfd}) {
+		t.Errorf("empty data should still be a zstd frame, got % x", empty)
+	}
+
+	data, _ = encodingx.NewLZ4().Marshal(compressPayload)
+	if decoded, err := io.ReadAll(lz4.NewReader(bytes.NewReader(data))); err != nil || !bytes.Equal(decoded, compressPayload) {
+		t.Errorf("lz4 interop failed: %v", err)
+	}
+
+	data, _ = encodingx.NewSnappyFramed().Marshal(compressPayload)
+	if !bytes.HasPrefix(data, []byte("\xff\x06\x00\x00sNaPpY")) {
+		t.Errorf("missing snappy stream identifier: % x", data[:10])
+	}
+
+	data, _ = encodingx.NewBrotli().Marshal(compressPayload)
+	if decoded, err := io.ReadAll(brotli.NewReader(bytes.NewReader(data))); err != nil || !bytes.Equal(decoded, compressPayload) {
+		t.Errorf("brotli interop failed: %v", err)
+	}
+}
+
+// TestCompressCodecsLevel 测试各自的压缩级别范围
+func TestCompressCodecsLevel(t *testing.T) {
+	cases := []struct {
+		name    string
+		fast    encodingx.Encoding
+		best    encodingx.Encoding
+		invalid encodingx.Encoding
+	}{
+		{"Zstd", encodingx.NewZstd(encodingx.WithLevel(1)), encodingx.NewZstd(encodingx.WithLevel(19)), nil},
+		{"LZ4", encodingx.NewLZ4(), encodingx.NewLZ4(encodingx.WithLevel(9)), encodingx.NewLZ4(encodingx.WithLevel(10))},
+		{"LZ4Block", encodingx.NewLZ4Block(), encodingx.NewLZ4Block(encodingx.WithLevel(9)), encodingx.NewLZ4Block(encodingx.WithLevel(-1))},
+		{"Brotli", encodingx.NewBrotli(encodingx.WithLevel(0)), encodingx.NewBrotli(encodingx.WithLevel(11)), encodingx.NewBrotli(encodingx.WithLevel(12))},
+	}
+	var input bytes.Buffer
+	for i := 0; input.Len() < 64<<10; i++ {
+		fmt.Fprintf(&input, "record %d: %s\n", i, strings.Repeat(string(rune('a'+i%26)), i%40))
+	}
+	for _, tc := range cases {
+		fast, err := tc.fast.Marshal(input.Bytes())
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", tc.name, err)
+		}
+		best, err := tc.best.Marshal(input.Bytes())
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", tc.name, err)
+		}
+		if len(best) >= len(fast) {
+			t.Errorf("%s: best level not smaller: %d >= %d", tc.name, len(best), len(fast))
+		}
+		var result encodingx.Bytes
+		if err := tc.fast.Unmarshal(best, &result); err != nil || !bytes.Equal(result.Data, input.Bytes()) {
+			t.Errorf("%s: decode of best level failed: %v", tc.name, err)
+		}
+		if tc.invalid != nil {
+			if _, err := tc.invalid.Marshal(input.Bytes()); err == nil {
+				t.Errorf("%s: expected error for invalid level", tc.name)
+			}
+		}
+	}
+	if _, err := encodingx.NewBrotli(encodingx.WithLevel(12)).Marshal(compressPayload); !errors.Is(err, encodingx.ErrBrotliInvalidLevel) {
+		t.Errorf("expected ErrBrotliInvalidLevel, got %v", err)
+	}
+}
+
+// TestZstdDictionary 测试训练字典
+func TestZstdDictionary(t *testing.T) {
+	var samples [][]byte
+	var history bytes.Buffer
+	for i := 0; i < 64; i++ {
+		sample := fmt.Sprintf(`{"event":"order_created","order_id":%d,"customer":{"tier":"gold","region":"eu-west-%d"},"items":[{"sku":"SKU-%04d","qty":%d}]}`, i, i%3, i*7, i%5+1)
+		samples = append(samples, []byte(sample))
+		history.WriteString(sample)
+	}
+	dict, err := zstd.BuildDict(zstd.BuildDictOptions{ID: 1001, Contents: samples, History: history.Bytes(), Offsets: [3]int{1, 4, 8}})
+	if err != nil {
+		t.Fatalf("BuildDict failed: %v", err)
+	}
+
+	input := []byte(`{"event":"order_created","order_id":4242,"customer":{"tier":"gold","region":"eu-west-2"},"items":[{"sku":"SKU-0099","qty":3}]}`)
+	withDict := encodingx.NewZstd(encodingx.WithDictionary(dict))
+	dictData, err := withDict.Marshal(input)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	plainData, _ := encodingx.NewZstd().Marshal(input)
+	if len(dictData) >= len(plainData) {
+		t.Errorf("dictionary did not help: %d >= %d", len(dictData), len(plainData))
+	}
+	var result encodingx.Bytes
+	if err := withDict.Unmarshal(dictData, &result); err != nil || !bytes.Equal(result.Data, input) {
+		t.Errorf("round trip failed: %v", err)
+	}
+	if err := encodingx.NewZstd().Unmarshal(dictData, &result); err == nil {
+		t.Error("expected error decoding without the dictionary")
+	}
+
+	if _, err := encodingx.NewZstd(encodingx.WithDictionary([]byte("not a dictionary"))).Marshal(input); err == nil {
+		t.Error("expected error for invalid dictionary")
+	}
+}
+
+// TestLZ4BlockInvalid 测试 LZ4Block 拒绝伪造的长度和损坏的块
+func TestLZ4BlockInvalid(t *testing.T) {
+	enc := encodingx.NewLZ4Block()
+	valid, _ := enc.Marshal(compressPayload)
+	cases := map[string][]byte{
+		"empty":          {},
+		"forged length":  append([]byte{0xff, 0xff, 0xff, 0xff, 0x0f}, valid[2:]...),
+		"missing block":  {0x05},
+		"trailing block": {0x00, 0x10},
+		"corrupt block":  append(append([]byte{}, valid[:2]...), bytes.Repeat([]byte{0xff}, 16)...),
+	}
+	for name, data := range cases {
+		var result encodingx.Bytes
+		if err := enc.Unmarshal(data, &result); !errors.Is(err, encodingx.ErrLZ4BlockInvalidData) {
+			t.Errorf("%s: expected ErrLZ4BlockInvalidData, got %v", name, err)
+		}
+	}
+}
+
+// TestCompressCodecsChain 测试压缩编码在链中的使用
+func TestCompressCodecsChain(t *testing.T) {
+	original := TestStruct{Integer: 18, String: strings.Repeat("message bus ", 100), Bool: true, Float: 0.5}
+	for _, name := range []string{"Zstd", "LZ4", "LZ4Block", "Snappy", "SnappyFramed", "Brotli"} {
+		chain := encodingx.MustParseChain("JSON|" + name + "|Base64")
+		data, err := chain.Marshal(original)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", name, err)
+		}
+		var result TestStruct
+		if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+			t.Errorf("%s: chain round trip failed: %v", name, err)
+		}
+
+		var buf bytes.Buffer
+		encoder := chain.NewEncoder(&buf)
+		if err := encoder.Encode(original); err != nil {
+			t.Fatalf("%s: Encode failed: %v", name, err)
+		}
+		if err := encoder.Close(); err != nil {
+			t.Fatalf("%s: Close failed: %v", name, err)
+		}
+		var streamed TestStruct
+		if err := chain.NewDecoder(&buf).Decode(&streamed); err != nil || !original.Equal(streamed) {
+			t.Errorf("%s: stream round trip failed: %v", name, err)
+		}
+	}
+}
+
+// ============================================================================
+// 与 HexZlib 的性能对比
+// ============================================================================
+
+func benchmarkUnmarshal(b *testing.B, enc encodingx.Encoding) {
+	data, err := enc.Marshal(benchmarkPayload)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.SetBytes(int64(len(benchmarkPayload)))
+	for i := 0; i < b.N; i++ {
+		var result encodingx.Bytes
+		if err := enc.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompressMarshal(b *testing.B) {
+	for _, enc := range append([]encodingx.Encoding{encodingx.NewHexZlib(), encodingx.NewZlib(), encodingx.NewGzip()}, compressCodecs...) {
+		b.Run(enc.String(), func(b *testing.B) { benchmarkMarshal(b, enc) })
+	}
+}
+
+func BenchmarkCompressUnmarshal(b *testing.B) {
+	for _, enc := range append([]encodingx.Encoding{encodingx.NewHexZlib(), encodingx.NewZlib(), encodingx.NewGzip()}, compressCodecs...) {
+		b.Run(enc.String(), func(b *testing.B) { benchmarkUnmarshal(b, enc) })
+	}
+}
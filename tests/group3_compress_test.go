@@ -0,0 +1,234 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// Gzip / Deflate / Zlib 压缩编码器测试
+// ============================================================================
+
+var compressPayload = []byte(strings.Repeat(`{"id":1,"name":"compressible payload","tags":["a","b"]}`, 40))
+
+// TestCompressRoundTrip 测试三种压缩格式的往返、格式头和流式输出
+func TestCompressRoundTrip(t *testing.T) {
+	cases := []struct {
+		encoding encodingx.StreamEncoding
+		magic    []byte
+	}{
+		{encodingx.NewGzip(), []byte{0x1f, 0x8b}},
+		{encodingx.NewDeflate(), nil},
+		{encodingx.NewZlib(), []byte{0x78}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.encoding.String(), func(t *testing.T) {
+			data, err := tc.encoding.Marshal(compressPayload)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if !bytes.HasPrefix(data, tc.magic) {
+				t.Errorf("unexpected header % x", data[:2])
+			}
+			if len(data) >= len(compressPayload)/4 {
+				t.Errorf("payload not compressed: %d bytes", len(data))
+			}
+			var result encodingx.Bytes
+			if err := tc.encoding.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, compressPayload) {
+				t.Errorf("round trip failed: %v", err)
+			}
+
+			// 流式输出与 Marshal 一致
+			var buf bytes.Buffer
+			encoder := tc.encoding.NewEncoder(&buf)
+			if err := encoder.Encode(compressPayload); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), data) {
+				t.Error("stream output differs from Marshal output")
+			}
+			decoded, err := io.ReadAll(tc.encoding.NewDecoder(bytes.NewReader(data)).(io.Reader))
+			if err != nil || !bytes.Equal(decoded, compressPayload) {
+				t.Errorf("stream decode failed: %v", err)
+			}
+
+			if err := tc.encoding.Unmarshal([]byte("not compressed"), &result); err == nil {
+				t.Error("expected error for corrupt data")
+			}
+			if err := tc.encoding.Unmarshal(data, new(string)); err == nil {
+				t.Error("expected error for wrong target type")
+			}
+		})
+	}
+
+	// 输出可被标准库直接解压
+	data, _ := encodingx.NewGzip().Marshal(compressPayload)
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("gzip.NewReader failed: %v", err)
+	}
+	if decoded, err := io.ReadAll(r); err != nil || !bytes.Equal(decoded, compressPayload) {
+		t.Errorf("gzip interop failed: %v", err)
+	}
+
+	if _, err := encodingx.NewGzip().Marshal(1); err != encodingx.ErrGzipWrongValueType {
+		t.Errorf("expected ErrGzipWrongValueType, got %v", err)
+	}
+	if _, err := encodingx.NewDeflate().Marshal(1); err != encodingx.ErrDeflateWrongValueType {
+		t.Errorf("expected ErrDeflateWrongValueType, got %v", err)
+	}
+	if _, err := encodingx.NewZlib().Marshal(1); err != encodingx.ErrZlibWrongValueType {
+		t.Errorf("expected ErrZlibWrongValueType, got %v", err)
+	}
+}
+
+// TestCompressLevel 测试压缩级别选项
+func TestCompressLevel(t *testing.T) {
+	for _, newEncoding := range []func(...encodingx.LevelOption) encodingx.StreamEncoding{
+		func(opts ...encodingx.LevelOption) encodingx.StreamEncoding {
+			return encodingx.NewGzip(asOptions[encodingx.CompressOption](opts)...)
+		},
+		func(opts ...encodingx.LevelOption) encodingx.StreamEncoding {
+			return encodingx.NewDeflate(asOptions[encodingx.DictionaryCompressOption](opts)...)
+		},
+		func(opts ...encodingx.LevelOption) encodingx.StreamEncoding {
+			return encodingx.NewZlib(asOptions[encodingx.DictionaryCompressOption](opts)...)
+		},
+	} {
+		best, err := newEncoding(encodingx.WithLevel(flate.BestCompression)).Marshal(compressPayload)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		stored, err := newEncoding(encodingx.WithLevel(flate.NoCompression)).Marshal(compressPayload)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if len(stored) <= len(compressPayload) || len(best) >= len(stored) {
+			t.Errorf("%s: unexpected sizes best=%d stored=%d", newEncoding(), len(best), len(stored))
+		}
+		var result encodingx.Bytes
+		if err := newEncoding().Unmarshal(stored, &result); err != nil || !bytes.Equal(result.Data, compressPayload) {
+			t.Errorf("%s: decode of stored data failed: %v", newEncoding(), err)
+		}
+
+		invalid := newEncoding(encodingx.WithLevel(42))
+		if _, err := invalid.Marshal(compressPayload); err == nil {
+			t.Errorf("%s: expected error for invalid level", invalid)
+		}
+		encoder := invalid.NewEncoder(new(bytes.Buffer))
+		if err := encoder.Encode(compressPayload); err == nil {
+			t.Errorf("%s: expected stream error for invalid level", invalid)
+		}
+	}
+}
+
+// TestCompressDictionary 测试预置字典
+func TestCompressDictionary(t *testing.T) {
+	dict := []byte(`"customer_name":"","shipping_address":"","billing_address":"","email_address":""`)
+	var records bytes.Buffer
+	for i := 0; i < 6; i++ {
+		fmt.Fprintf(&records, `{"customer_name":"N%d","email_address":"e%d@x.y","shipping_address":"%d Road"}`, i, i*7, i*3)
+	}
+	input := records.Bytes()
+	for _, pair := range [][2]encodingx.Encoding{
+		{encodingx.NewDeflate(), encodingx.NewDeflate(encodingx.WithDictionary(dict))},
+		{encodingx.NewZlib(), encodingx.NewZlib(encodingx.WithDictionary(dict))},
+		{encodingx.NewHexZlib(), encodingx.NewHexZlib(encodingx.WithDictionary(dict))},
+	} {
+		plain, withDict := pair[0], pair[1]
+		plainData, _ := plain.Marshal(input)
+		dictData, err := withDict.Marshal(input)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if len(dictData) >= len(plainData) {
+			t.Errorf("%s: dictionary did not help: %d >= %d", plain, len(dictData), len(plainData))
+		}
+		var result encodingx.Bytes
+		if err := withDict.Unmarshal(dictData, &result); err != nil || !bytes.Equal(result.Data, input) {
+			t.Errorf("%s: round trip failed: %v", plain, err)
+		}
+		if err := plain.Unmarshal(dictData, &result); err == nil && bytes.Equal(result.Data, input) {
+			t.Errorf("%s: decoded without the dictionary", plain)
+		}
+	}
+}
+
+// TestCompressChain 测试压缩编码在链中的使用，包括流式管道
+func TestCompressChain(t *testing.T) {
+	original := TestStruct{Integer: 17, String: strings.Repeat("gzip me ", 200), Bool: true, Float: 3.5}
+	for _, spec := range []string{"JSON|Gzip|Base64", "JSON|Deflate|Hex", "YAML|Zlib|Base64URL"} {
+		t.Run(spec, func(t *testing.T) {
+			chain := encodingx.MustParseChain(spec)
+			data, err := chain.Marshal(original)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var result TestStruct
+			if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+				t.Errorf("round trip failed: %v", err)
+			}
+
+			var buf bytes.Buffer
+			encoder := chain.NewEncoder(&buf)
+			if err := encoder.Encode(original); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+			var streamed TestStruct
+			if err := chain.NewDecoder(&buf).Decode(&streamed); err != nil || !original.Equal(streamed) {
+				t.Errorf("stream round trip failed: %v", err)
+			}
+		})
+	}
+}
+
+// TestHexZlibComposition 测试 HexZlib 与 "Zlib|HexTier" 组合输出一致
+func TestHexZlibComposition(t *testing.T) {
+	composed := encodingx.MustParseChain("Zlib|HexTier")
+	for _, input := range [][]byte{{}, []byte("a"), compressPayload} {
+		expected, err := encodingx.NewHexZlib().Marshal(input)
+		if err != nil {
+			t.Fatalf("HexZlib Marshal failed: %v", err)
+		}
+		data, err := composed.Marshal(encodingx.Bytes{Data: input})
+		if err != nil {
+			t.Fatalf("chain Marshal failed: %v", err)
+		}
+		if !bytes.Equal(data, expected) {
+			t.Errorf("composition differs for %d bytes", len(input))
+		}
+		var result encodingx.Bytes
+		if err := encodingx.NewHexZlib().Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, input) {
+			t.Errorf("HexZlib cannot decode composed output: %v", err)
+		}
+	}
+}
+
+// TestCompressContext 测试已取消的上下文中断压缩与解压
+func TestCompressContext(t *testing.T) {
+	for _, enc := range []encodingx.ContextEncoding{encodingx.NewGzip(), encodingx.NewDeflate(), encodingx.NewZlib()} {
+		if _, err := enc.MarshalContext(canceledContext(), compressPayload); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got %v", enc, err)
+		}
+		data, _ := enc.Marshal(compressPayload)
+		var result encodingx.Bytes
+		if err := enc.UnmarshalContext(canceledContext(), data, &result); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: expected context.Canceled, got %v", enc, err)
+		}
+	}
+}
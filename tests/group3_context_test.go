@@ -0,0 +1,239 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// ContextEncoding 取消与超时测试
+// ============================================================================
+
+// countdownContext 在 Err 被调用指定次数后变为已取消，用于模拟处理过程中断开
+type countdownContext struct {
+	context.Context
+	remaining int64
+}
+
+func newCountdownContext(checks int64) *countdownContext {
+	return &countdownContext{Context: context.Background(), remaining: checks}
+}
+
+func (c *countdownContext) Done() <-chan struct{} {
+	return make(chan struct{})
+}
+
+func (c *countdownContext) Err() error {
+	if atomic.AddInt64(&c.remaining, -1) < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+// TestContextEncodingsCanceled 测试已取消的 context 使各编码器立即失败
+func TestContextEncodingsCanceled(t *testing.T) {
+	encodings := []encodingx.ContextEncoding{
+		encodingx.NewTOML(),
+		encodingx.NewYAML(),
+		encodingx.NewCSV(),
+		encodingx.NewCSVWithHeaders(),
+		encodingx.NewHexZlib(),
+		encodingx.MustParseChain("JSON|Base64"),
+	}
+	ctx := canceledContext()
+	for _, enc := range encodings {
+		t.Run(enc.String(), func(t *testing.T) {
+			if _, err := enc.MarshalContext(ctx, []byte("x")); !errors.Is(err, context.Canceled) {
+				t.Errorf("MarshalContext: expected context.Canceled, got %v", err)
+			}
+			var result encodingx.Bytes
+			if err := enc.UnmarshalContext(ctx, []byte("x"), &result); !errors.Is(err, context.Canceled) {
+				t.Errorf("UnmarshalContext: expected context.Canceled, got %v", err)
+			}
+		})
+	}
+}
+
+// TestContextEncodingsBackground 测试未取消时与 Marshal/Unmarshal 行为一致
+func TestContextEncodingsBackground(t *testing.T) {
+	ctx := context.Background()
+	original := TestStruct{Integer: 3, String: "ctx", Bool: true, Float: 0.5}
+	for _, enc := range []encodingx.ContextEncoding{encodingx.NewTOML(), encodingx.NewYAML()} {
+		expected, err := enc.Marshal(original)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", enc, err)
+		}
+		data, err := enc.MarshalContext(ctx, original)
+		if err != nil {
+			t.Fatalf("%s MarshalContext failed: %v", enc, err)
+		}
+		if !bytes.Equal(data, expected) {
+			t.Errorf("%s MarshalContext output differs from Marshal", enc)
+		}
+		var result TestStruct
+		if err := enc.UnmarshalContext(ctx, data, &result); err != nil {
+			t.Fatalf("%s UnmarshalContext failed: %v", enc, err)
+		}
+		if !original.Equal(result) {
+			t.Errorf("%s round trip failed", enc)
+		}
+	}
+
+	// 空 YAML 文档与 Unmarshal 一致，不报错
+	var result TestStruct
+	if err := encodingx.NewYAML().UnmarshalContext(ctx, nil, &result); err != nil {
+		t.Errorf("empty YAML document should decode, got %v", err)
+	}
+
+	records := []*CSVRecord{{ID: 1, Name: "a", Value: 1}}
+	data, err := encodingx.NewCSV().MarshalContext(ctx, records)
+	if err != nil {
+		t.Fatalf("CSV MarshalContext failed: %v", err)
+	}
+	var decoded []*CSVRecord
+	if err := encodingx.NewCSV().UnmarshalContext(ctx, data, &decoded); err != nil || !CSVRecordsEqual(decoded, records) {
+		t.Errorf("CSV round trip failed: %v", err)
+	}
+}
+
+// TestContextCanceledMidway 测试处理过程中取消可以中止大数据的解码和解压
+func TestContextCanceledMidway(t *testing.T) {
+	records := make([]*CSVRecord, 20000)
+	for i := range records {
+		records[i] = &CSVRecord{ID: i, Name: "row", Value: float64(i)}
+	}
+	data, err := encodingx.NewCSV().Marshal(records)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded []*CSVRecord
+	err = encodingx.NewCSV().UnmarshalContext(newCountdownContext(10), data, &decoded)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CSV: expected context.Canceled, got %v", err)
+	}
+
+	yamlData, err := encodingx.NewYAML().Marshal(records)
+	if err != nil {
+		t.Fatalf("YAML Marshal failed: %v", err)
+	}
+	var yamlDecoded []*CSVRecord
+	err = encodingx.NewYAML().UnmarshalContext(newCountdownContext(10), yamlData, &yamlDecoded)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("YAML: expected context.Canceled, got %v", err)
+	}
+
+	zlibData, err := encodingx.NewHexZlib().Marshal(bytes.Repeat([]byte("z"), 1<<20))
+	if err != nil {
+		t.Fatalf("HexZlib Marshal failed: %v", err)
+	}
+	var inflated encodingx.Bytes
+	err = encodingx.NewHexZlib().UnmarshalContext(newCountdownContext(3), zlibData, &inflated)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("HexZlib: expected context.Canceled, got %v", err)
+	}
+}
+
+// cancelEncoding 是测试用的字节编码器，在 Marshal 时取消 context
+type cancelEncoding struct {
+	cancel context.CancelFunc
+}
+
+func (cancelEncoding) String() string {
+	return "Cancel"
+}
+
+func (cancelEncoding) Style() encodingx.EncodingStyleType {
+	return encodingx.EncodingStyleBytes
+}
+
+func (e cancelEncoding) Marshal(v interface{}) ([]byte, error) {
+	e.cancel()
+	return encodingx.NewLazy().Marshal(v)
+}
+
+func (cancelEncoding) Unmarshal(data []byte, v interface{}) error {
+	return encodingx.NewLazy().Unmarshal(data, v)
+}
+
+func (e cancelEncoding) Reverse() encodingx.Encoding {
+	return e
+}
+
+// TestChainContextStage 测试链在阶段之间检查 context 并报告所在阶段
+func TestChainContextStage(t *testing.T) {
+	// 第二个阶段执行时取消，第三个阶段开始前应报告取消
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	registry := encodingx.DefaultRegistry().NewChild()
+	registry.MustRegister(cancelEncoding{cancel: cancel})
+	chain, err := registry.ParseChain("JSON|Cancel|Hex")
+	if err != nil {
+		t.Fatalf("ParseChain failed: %v", err)
+	}
+	_, err = chain.MarshalContext(ctx, TestStruct{})
+	var chainErr *encodingx.ChainError
+	if !errors.As(err, &chainErr) || chainErr.Stage != 2 || !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation at stage 2, got %v", err)
+	}
+
+	timeout, cancelTimeout := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancelTimeout()
+	<-timeout.Done()
+	err = chain.UnmarshalContext(timeout, []byte("00"), &TestStruct{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+
+	// 非 ContextEncoding 编码器在开始前检查
+	if _, err := encodingx.MarshalContext(canceledContext(), encodingx.NewJSON(), TestStruct{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if err := encodingx.UnmarshalContext(canceledContext(), encodingx.NewJSON(), []byte("{}"), &TestStruct{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+// TestChainStreamContext 测试流式管道在传输过程中响应取消
+func TestChainStreamContext(t *testing.T) {
+	chain := encodingx.MustParseChain("Lazy|Base64|Hex")
+	payload := bytes.Repeat([]byte("stream"), 100000)
+
+	var buf bytes.Buffer
+	encoder := chain.NewEncoderContext(newCountdownContext(5), &buf)
+	err := encoder.Encode(payload)
+	if err == nil {
+		err = encoder.Close()
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("encoder: expected context.Canceled, got %v", err)
+	}
+
+	data, err := chain.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result encodingx.Bytes
+	err = chain.NewDecoderContext(newCountdownContext(5), bytes.NewReader(data)).Decode(&result)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("decoder: expected context.Canceled, got %v", err)
+	}
+
+	// 未取消时正常工作
+	err = chain.NewDecoderContext(context.Background(), strings.NewReader(string(data))).Decode(&result)
+	if err != nil || !bytes.Equal(result.Data, payload) {
+		t.Errorf("decoder without cancellation failed: %v", err)
+	}
+}
@@ -0,0 +1,233 @@
+package encodingx_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// FormURLEncoded 编码器测试
+// ============================================================================
+
+// FormAddress 是嵌套的表单结构体
+type FormAddress struct {
+	City string `form:"city"`
+	Zip  string `form:"zip,omitempty"`
+}
+
+// FormItem 是切片元素结构体
+type FormItem struct {
+	SKU string `form:"sku"`
+	Qty int    `form:"qty"`
+}
+
+// FormBase 用于测试匿名嵌入字段的展开
+type FormBase struct {
+	ID int64 `form:"id"`
+}
+
+// FormOrder 是表单编码测试结构体
+type FormOrder struct {
+	FormBase
+	Name     string            `form:"name"`
+	Tags     []string          `form:"tag"`
+	Paid     bool              `form:"paid"`
+	Amount   float64           `form:"amount"`
+	Address  FormAddress       `form:"address"`
+	Billing  *FormAddress      `form:"billing"`
+	Items    []FormItem        `form:"items"`
+	Meta     map[string]string `form:"meta"`
+	Created  time.Time         `form:"created"`
+	Note     string            `form:"note,omitempty"`
+	Secret   string            `form:"-"`
+	Untagged uint8
+	hidden   string
+}
+
+// TestFormURLEncodedStruct 测试结构体的编码格式与往返
+func TestFormURLEncodedStruct(t *testing.T) {
+	created := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	original := FormOrder{
+		FormBase: FormBase{ID: 42},
+		Name:     "a b&c",
+		Tags:     []string{"x", "y"},
+		Paid:     true,
+		Amount:   9.5,
+		Address:  FormAddress{City: "Paris"},
+		Items:    []FormItem{{SKU: "s1", Qty: 1}, {SKU: "s2", Qty: 2}},
+		Meta:     map[string]string{"k": "v"},
+		Created:  created,
+		Secret:   "secret",
+		Untagged: 7,
+		hidden:   "hidden",
+	}
+	enc := encodingx.NewFormURLEncoded()
+	data, err := enc.Marshal(&original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		t.Fatalf("output is not form encoded: %v", err)
+	}
+	expected := url.Values{
+		"id":            {"42"},
+		"name":          {"a b&c"},
+		"tag":           {"x", "y"},
+		"paid":          {"true"},
+		"amount":        {"9.5"},
+		"address[city]": {"Paris"},
+		"items[0][sku]": {"s1"},
+		"items[0][qty]": {"1"},
+		"items[1][sku]": {"s2"},
+		"items[1][qty]": {"2"},
+		"meta[k]":       {"v"},
+		"created":       {"2024-05-06T07:08:09Z"},
+		"Untagged":      {"7"},
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("unexpected values:\n%v\nexpected:\n%v", values, expected)
+	}
+
+	var result FormOrder
+	if err := enc.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	original.Secret, original.hidden = "", ""
+	if !reflect.DeepEqual(result, original) {
+		t.Errorf("round trip mismatch:\n%+v\n%+v", result, original)
+	}
+	if result.Billing != nil {
+		t.Error("absent nested pointer should stay nil")
+	}
+}
+
+// TestFormURLEncodedMap 测试 map 与 url.Values 的编码
+func TestFormURLEncodedMap(t *testing.T) {
+	enc := encodingx.NewFormURLEncoded()
+	data, err := enc.Marshal(url.Values{"b": {"2", "3"}, "a": {"1"}})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != "a=1&b=2&b=3" {
+		t.Errorf("unexpected output %q", data)
+	}
+
+	var values url.Values
+	if err := enc.Unmarshal(data, &values); err != nil || !reflect.DeepEqual(values, url.Values{"a": {"1"}, "b": {"2", "3"}}) {
+		t.Errorf("Unmarshal into url.Values failed: %v, %v", values, err)
+	}
+
+	var generic map[string]interface{}
+	if err := enc.Unmarshal([]byte("a=1&b=2&b=3&user[name]=x&user[roles]=r1&user[roles]=r2"), &generic); err != nil {
+		t.Fatalf("Unmarshal into map failed: %v", err)
+	}
+	expected := map[string]interface{}{
+		"a": "1",
+		"b": []string{"2", "3"},
+		"user": map[string]interface{}{
+			"name":  "x",
+			"roles": []string{"r1", "r2"},
+		},
+	}
+	if !reflect.DeepEqual(generic, expected) {
+		t.Errorf("unexpected map %v", generic)
+	}
+
+	nested, err := enc.Marshal(map[string]interface{}{"user": map[string]interface{}{"name": "x", "ids": []int{1, 2}}})
+	if err != nil {
+		t.Fatalf("Marshal nested map failed: %v", err)
+	}
+	if string(nested) != "user%5Bids%5D=1&user%5Bids%5D=2&user%5Bname%5D=x" {
+		t.Errorf("unexpected nested output %q", nested)
+	}
+}
+
+// TestFormURLEncodedErrors 测试错误类型与非法值
+func TestFormURLEncodedErrors(t *testing.T) {
+	enc := encodingx.NewFormURLEncoded()
+	for _, v := range []interface{}{"string", 1, []string{"a"}, nil} {
+		if _, err := enc.Marshal(v); !errors.Is(err, encodingx.ErrFormURLEncodedWrongValueType) {
+			t.Errorf("Marshal(%v): expected ErrFormURLEncodedWrongValueType, got %v", v, err)
+		}
+	}
+	if _, err := enc.Marshal(map[int]string{1: "a"}); !errors.Is(err, encodingx.ErrFormURLEncodedWrongValueType) {
+		t.Errorf("expected ErrFormURLEncodedWrongValueType for int keys, got %v", err)
+	}
+	if err := enc.Unmarshal([]byte("a=1"), FormOrder{}); !errors.Is(err, encodingx.ErrFormURLEncodedWrongValueType) {
+		t.Errorf("expected ErrFormURLEncodedWrongValueType for non-pointer, got %v", err)
+	}
+
+	var order FormOrder
+	if err := enc.Unmarshal([]byte("paid=maybe"), &order); err == nil {
+		t.Error("expected error for invalid bool")
+	}
+	if err := enc.Unmarshal([]byte("Untagged=300"), &order); err == nil {
+		t.Error("expected error for uint8 overflow")
+	}
+	if err := enc.Unmarshal([]byte("items[x][sku]=1"), &order); err == nil {
+		t.Error("expected error for invalid index")
+	}
+	if err := enc.Unmarshal([]byte("a=%zz"), &order); err == nil {
+		t.Error("expected error for invalid escape")
+	}
+}
+
+// TestFormURLEncodedIndexes 测试下标必须从 0 连续，长度不由下标决定
+func TestFormURLEncodedIndexes(t *testing.T) {
+	enc := encodingx.NewFormURLEncoded()
+	for _, input := range []string{
+		"items[50000000][sku]=x",
+		"items[0][sku]=a&items[2][sku]=c",
+		"items[01][sku]=x&items[0][sku]=a",
+		"items[-1][sku]=x",
+	} {
+		var order FormOrder
+		start := time.Now()
+		if err := enc.Unmarshal([]byte(input), &order); err == nil {
+			t.Errorf("%s: expected error, got %d items", input, len(order.Items))
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%s: took %v", input, elapsed)
+		}
+	}
+
+	var order FormOrder
+	if err := enc.Unmarshal([]byte("items[1][sku]=b&items[0][sku]=a"), &order); err != nil || len(order.Items) != 2 || order.Items[1].SKU != "b" {
+		t.Errorf("dense indexes failed: %+v, %v", order.Items, err)
+	}
+
+	// 不写出任何键的元素保留其下标
+	original := map[string][]map[string]string{"rows": {{"a": "1"}, {}, {"b": "2"}}}
+	data, err := enc.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result map[string][]map[string]string
+	if err := enc.Unmarshal(data, &result); err != nil || !reflect.DeepEqual(result, original) {
+		t.Errorf("round trip with empty element failed: %s -> %v, %v", data, result, err)
+	}
+}
+
+// TestFormURLEncodedChain 测试表单编码可作为链的第一阶段
+func TestFormURLEncodedChain(t *testing.T) {
+	chain, err := encodingx.ParseChain("FormURLEncoded|PercentEncodingQuery")
+	if err != nil {
+		t.Fatalf("ParseChain failed: %v", err)
+	}
+	original := FormAddress{City: "New York", Zip: "10001"}
+	data, err := chain.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result FormAddress
+	if err := chain.Unmarshal(data, &result); err != nil || result != original {
+		t.Errorf("chain round trip failed: %+v, %v", result, err)
+	}
+}
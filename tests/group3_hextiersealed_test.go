@@ -0,0 +1,237 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// HexTierSealed 编码器测试
+// ============================================================================
+
+var sealedSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// TestHexTierSealedRoundTrip 测试往返与 2 的幂次分级填充，每级有 28 字节开销
+func TestHexTierSealedRoundTrip(t *testing.T) {
+	enc := encodingx.NewHexTierSealed(sealedSecret)
+	cases := []struct {
+		size int
+		tier int
+	}{
+		{0, 32},
+		{1, 32},
+		{4, 32},
+		{5, 64},
+		{36, 64},
+		{37, 128},
+		{996, 1024},
+		{997, 2048},
+	}
+	for _, tc := range cases {
+		input := []byte(strings.Repeat("x", tc.size))
+		encoded, err := enc.Marshal(input)
+		if err != nil {
+			t.Fatalf("size %d: Marshal failed: %v", tc.size, err)
+		}
+		if len(encoded) != tc.tier*2 {
+			t.Errorf("size %d: got %d hex chars, want tier %d", tc.size, len(encoded), tc.tier)
+		}
+		var result encodingx.Bytes
+		if err := enc.Unmarshal(encoded, &result); err != nil {
+			t.Fatalf("size %d: Unmarshal failed: %v", tc.size, err)
+		}
+		if !bytes.Equal(result.Data, input) {
+			t.Errorf("size %d: roundtrip mismatch", tc.size)
+		}
+	}
+}
+
+// TestHexTierSealedSecretCopied 测试构造后修改密钥不影响编码
+func TestHexTierSealedSecretCopied(t *testing.T) {
+	secret := bytes.Clone(sealedSecret)
+	enc := encodingx.NewHexTierSealed(secret)
+	clear(secret)
+	encoded, err := enc.Marshal([]byte("copied"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result encodingx.Bytes
+	if err := encodingx.NewHexTierSealed(sealedSecret).Unmarshal(encoded, &result); err != nil || string(result.Data) != "copied" {
+		t.Errorf("clearing the secret after construction changed the encoding: %v", err)
+	}
+}
+
+// TestHexTierSealedRandom 测试输出不含明文、长度与填充，且每次不同
+func TestHexTierSealedRandom(t *testing.T) {
+	enc := encodingx.NewHexTierSealed(sealedSecret)
+	input := []byte("portal")
+	first, _ := enc.Marshal(input)
+	second, _ := enc.Marshal(input)
+	if bytes.Equal(first, second) {
+		t.Error("two Marshal calls gave the same output")
+	}
+
+	decoded, err := hex.DecodeString(string(first))
+	if err != nil {
+		t.Fatalf("output is not hex: %v", err)
+	}
+	if bytes.Contains(decoded, input) {
+		t.Error("output contains the plaintext")
+	}
+	// 明文长度与零填充都不应出现
+	if bytes.Contains(decoded, []byte{0, 0, 0, byte(len(input))}) || bytes.Contains(decoded, make([]byte, 4)) {
+		t.Errorf("output shows the length or the padding: % x", decoded)
+	}
+}
+
+// TestHexTierSealedTamper 测试篡改与错误密钥返回 ErrAuthentication
+func TestHexTierSealedTamper(t *testing.T) {
+	enc := encodingx.NewHexTierSealed(sealedSecret)
+	encoded, err := enc.Marshal([]byte(`{"uid":1}`))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result encodingx.Bytes
+	for i := range encoded {
+		tampered := bytes.Clone(encoded)
+		if tampered[i] == '0' {
+			tampered[i] = '1'
+		} else {
+			tampered[i] = '0'
+		}
+		if err := enc.Unmarshal(tampered, &result); !errors.Is(err, encodingx.ErrAuthentication) {
+			t.Errorf("hex char %d: expected ErrAuthentication, got %v", i, err)
+		}
+	}
+
+	other := encodingx.NewHexTierSealed([]byte("another secret of thirty-two b!!"))
+	if err := other.Unmarshal(encoded, &result); !errors.Is(err, encodingx.ErrAuthentication) {
+		t.Errorf("other secret: expected ErrAuthentication, got %v", err)
+	}
+
+	// 非分级长度与非十六进制输入
+	for _, input := range [][]byte{encoded[:len(encoded)-2], encoded[:32], []byte("zz")} {
+		if err := enc.Unmarshal(input, &result); err == nil {
+			t.Errorf("len %d: expected error", len(input))
+		}
+	}
+	if err := enc.Unmarshal(encoded[:len(encoded)-2], &result); !errors.Is(err, encodingx.ErrHexInvalidData) {
+		t.Errorf("expected ErrHexInvalidData, got %v", err)
+	}
+}
+
+// TestHexTierSealedLegacy 测试 WithLegacyDecode 兼容 HexTierRand 旧格式
+func TestHexTierSealedLegacy(t *testing.T) {
+	legacy, err := encodingx.NewHexTierRand().Marshal([]byte("issued before"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result encodingx.Bytes
+	if err := encodingx.NewHexTierSealed(sealedSecret).Unmarshal(legacy, &result); err == nil {
+		t.Error("legacy data accepted without WithLegacyDecode")
+	}
+
+	migrating := encodingx.NewHexTierSealed(sealedSecret, encodingx.WithLegacyDecode())
+	if err := migrating.Unmarshal(legacy, &result); err != nil || string(result.Data) != "issued before" {
+		t.Errorf("legacy Unmarshal failed: %v", err)
+	}
+	sealed, _ := migrating.Marshal([]byte("issued after"))
+	if err := migrating.Unmarshal(sealed, &result); err != nil || string(result.Data) != "issued after" {
+		t.Errorf("sealed Unmarshal failed: %v", err)
+	}
+	// 旧格式仍可由 HexTierRand 解码
+	if err := encodingx.NewHexTierRand().Unmarshal(legacy, &result); err != nil || string(result.Data) != "issued before" {
+		t.Errorf("HexTierRand Unmarshal failed: %v", err)
+	}
+}
+
+// TestHexTierSealedLegacyPolicy 测试旧格式按实例的分级策略解码
+func TestHexTierSealedLegacyPolicy(t *testing.T) {
+	policy := encodingx.WithTierPolicy(encodingx.TierLinear(48))
+	legacy, err := encodingx.NewHexTierRand(policy).Marshal([]byte("issued before"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if len(legacy) != 96 {
+		t.Fatalf("expected a 48 byte tier, got %d", len(legacy)/2)
+	}
+	var result encodingx.Bytes
+	migrating := encodingx.NewHexTierSealed(sealedSecret, encodingx.WithLegacyDecode(), policy)
+	if err := migrating.Unmarshal(legacy, &result); err != nil || string(result.Data) != "issued before" {
+		t.Errorf("legacy Unmarshal with policy failed: %v", err)
+	}
+	if err := encodingx.NewHexTierSealed(sealedSecret, encodingx.WithLegacyDecode()).Unmarshal(legacy, &result); err == nil {
+		t.Error("legacy data of another policy accepted")
+	}
+}
+
+// TestHexTierSealedLegacyTamper 测试篡改后的密封数据不会作为旧格式解码
+func TestHexTierSealedLegacyTamper(t *testing.T) {
+	migrating := encodingx.NewHexTierSealed(sealedSecret, encodingx.WithLegacyDecode())
+	for _, size := range []int{0, 4, 36, 100} {
+		encoded, err := migrating.Marshal([]byte(strings.Repeat("t", size)))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		var result encodingx.Bytes
+		for i := range encoded {
+			tampered := bytes.Clone(encoded)
+			if tampered[i] == '0' {
+				tampered[i] = '1'
+			} else {
+				tampered[i] = '0'
+			}
+			if err := migrating.Unmarshal(tampered, &result); !errors.Is(err, encodingx.ErrAuthentication) {
+				t.Errorf("size %d, hex char %d: expected ErrAuthentication, got %v", size, i, err)
+			}
+		}
+	}
+
+	// 填充超出其分级的旧格式帧不是 HexTierRand 写出的，按认证失败处理
+	legacy, _ := encodingx.NewHexTierRand().Marshal([]byte("issued before"))
+	padded := append(bytes.Clone(legacy), bytes.Repeat([]byte("ab"), len(legacy)/2)...)
+	var result encodingx.Bytes
+	if err := migrating.Unmarshal(padded, &result); !errors.Is(err, encodingx.ErrAuthentication) {
+		t.Errorf("over-padded legacy frame: expected ErrAuthentication, got %v", err)
+	}
+}
+
+// TestHexTierSealedInvalid 测试缺少密钥与错误的值类型
+func TestHexTierSealedInvalid(t *testing.T) {
+	if _, err := encodingx.NewHexTierSealed(nil).Marshal([]byte("x")); !errors.Is(err, encodingx.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+	enc := encodingx.NewHexTierSealed(sealedSecret)
+	if _, err := enc.Marshal(42); !errors.Is(err, encodingx.ErrHexWrongValueType) {
+		t.Errorf("expected ErrHexWrongValueType, got %v", err)
+	}
+	encoded, _ := enc.Marshal([]byte("x"))
+	var s string
+	if err := enc.Unmarshal(encoded, &s); !errors.Is(err, encodingx.ErrHexWrongValueType) {
+		t.Errorf("expected ErrHexWrongValueType, got %v", err)
+	}
+}
+
+// TestHexTierSealedChain 测试注册后在链式编码中使用
+func TestHexTierSealedChain(t *testing.T) {
+	registry := encodingx.DefaultRegistry().NewChild()
+	registry.MustRegister(encodingx.NewHexTierSealed(sealedSecret))
+	chain, err := registry.ParseChain("JSON|HexTierSealed")
+	if err != nil {
+		t.Fatalf("ParseChain failed: %v", err)
+	}
+	original := TestStruct{Integer: 24, String: "sealed", Bool: true}
+	data, err := chain.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result TestStruct
+	if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+		t.Errorf("chain round trip failed: %v", err)
+	}
+}
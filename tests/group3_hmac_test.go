@@ -0,0 +1,195 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// HMAC 签名校验测试
+// ============================================================================
+
+var hmacKey = []byte("0123456789abcdef0123456789abcdef")
+
+// TestHMACRoundTrip 测试三种哈希的签名往返与 MAC 长度
+func TestHMACRoundTrip(t *testing.T) {
+	cases := []struct {
+		hash crypto.Hash
+		size int
+	}{
+		{0, 32},
+		{crypto.SHA256, 32},
+		{crypto.SHA384, 48},
+		{crypto.SHA512, 64},
+	}
+	for _, tc := range cases {
+		encoding := encodingx.NewHMAC(encodingx.StaticKey(hmacKey), encodingx.WithHash(tc.hash))
+		for _, plain := range [][]byte{nil, []byte("token"), compressPayload} {
+			data, err := encoding.Marshal(plain)
+			if err != nil {
+				t.Fatalf("%v: Marshal failed: %v", tc.hash, err)
+			}
+			if len(data) != 1+len(plain)+tc.size {
+				t.Errorf("%v: unexpected length %d for %d bytes", tc.hash, len(data), len(plain))
+			}
+			if !bytes.Equal(data[1:1+len(plain)], plain) {
+				t.Errorf("%v: data is not kept in the clear", tc.hash)
+			}
+			var decoded encodingx.Bytes
+			if err := encoding.Unmarshal(data, &decoded); err != nil || !bytes.Equal(decoded.Data, plain) {
+				t.Errorf("%v: Unmarshal failed: %v", tc.hash, err)
+			}
+		}
+	}
+}
+
+// TestHMACCompatible 测试 MAC 与标准库 crypto/hmac 计算结果一致
+func TestHMACCompatible(t *testing.T) {
+	data, err := encodingx.NewHMAC(encodingx.StaticKey(hmacKey)).Marshal([]byte("token"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	mac := hmac.New(sha256.New, hmacKey)
+	mac.Write([]byte("\x00token"))
+	if want := append([]byte("\x00token"), mac.Sum(nil)...); !bytes.Equal(data, want) {
+		t.Errorf("got % x, want % x", data, want)
+	}
+}
+
+// TestHMACTamper 测试任意字节被篡改或截断时返回 ErrAuthentication
+func TestHMACTamper(t *testing.T) {
+	encoding := encodingx.NewHMAC(encodingx.StaticKey(hmacKey))
+	data, err := encoding.Marshal([]byte(`{"user":1,"admin":false}`))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded encodingx.Bytes
+	for i := range data {
+		tampered := bytes.Clone(data)
+		tampered[i] ^= 0x01
+		if err := encoding.Unmarshal(tampered, &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+			t.Errorf("byte %d: expected ErrAuthentication, got %v", i, err)
+		}
+	}
+	for _, n := range []int{0, 1, 32, len(data) - 1} {
+		if err := encoding.Unmarshal(data[:n], &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+			t.Errorf("truncated to %d: expected ErrAuthentication, got %v", n, err)
+		}
+	}
+
+	other := encodingx.NewHMAC(encodingx.StaticKey([]byte("another key of thirty-two bytes!")))
+	if err := other.Unmarshal(data, &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+		t.Errorf("other key: expected ErrAuthentication, got %v", err)
+	}
+	sha512 := encodingx.NewHMAC(encodingx.StaticKey(hmacKey), encodingx.WithHash(crypto.SHA512))
+	if err := sha512.Unmarshal(data, &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+		t.Errorf("other hash: expected ErrAuthentication, got %v", err)
+	}
+}
+
+// TestHMACKeyRotation 测试轮换后旧令牌仍可校验，新令牌使用当前密钥
+func TestHMACKeyRotation(t *testing.T) {
+	ring, err := encodingx.NewKeyRing("k1", hmacKey)
+	if err != nil {
+		t.Fatalf("NewKeyRing failed: %v", err)
+	}
+	encoding := encodingx.NewHMAC(ring)
+	old, err := encoding.Marshal([]byte("old token"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if err := ring.Rotate("k2", []byte("a fresh key for the second round")); err != nil {
+		t.Fatalf("Rotate failed: %v", err)
+	}
+	fresh, err := encoding.Marshal([]byte("new token"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !bytes.HasPrefix(old, []byte("\x02k1")) || !bytes.HasPrefix(fresh, []byte("\x02k2")) {
+		t.Errorf("unexpected key IDs % x and % x", old[:3], fresh[:3])
+	}
+
+	var decoded encodingx.Bytes
+	if err := encoding.Unmarshal(old, &decoded); err != nil || string(decoded.Data) != "old token" {
+		t.Errorf("old token failed after rotation: %v", err)
+	}
+	if err := encoding.Unmarshal(fresh, &decoded); err != nil || string(decoded.Data) != "new token" {
+		t.Errorf("new token failed: %v", err)
+	}
+
+	// 移除旧密钥后旧令牌失效
+	ring.Remove("k1")
+	err = encoding.Unmarshal(old, &decoded)
+	if !errors.Is(err, encodingx.ErrAuthentication) || !errors.Is(err, encodingx.ErrKeyNotFound) {
+		t.Errorf("removed key: expected ErrAuthentication and ErrKeyNotFound, got %v", err)
+	}
+	if err := encoding.Unmarshal(fresh, &decoded); err != nil {
+		t.Errorf("new token failed after removal: %v", err)
+	}
+
+	// 移除当前密钥后无法签名
+	ring.Remove("k2")
+	if _, err := encoding.Marshal([]byte("token")); !errors.Is(err, encodingx.ErrKeyNotFound) {
+		t.Errorf("no current key: expected ErrKeyNotFound, got %v", err)
+	}
+}
+
+// TestHMACInvalid 测试不支持的哈希、过长的密钥 ID 和错误的值类型
+func TestHMACInvalid(t *testing.T) {
+	md5 := encodingx.NewHMAC(encodingx.StaticKey(hmacKey), encodingx.WithHash(crypto.MD5))
+	if _, err := md5.Marshal([]byte("token")); !errors.Is(err, encodingx.ErrHMACUnsupportedHash) {
+		t.Errorf("expected ErrHMACUnsupportedHash, got %v", err)
+	}
+	if _, err := encodingx.NewKeyRing(strings.Repeat("k", 256), hmacKey); !errors.Is(err, encodingx.ErrKeyIDTooLong) {
+		t.Errorf("expected ErrKeyIDTooLong, got %v", err)
+	}
+	if _, err := encodingx.NewHMAC(nil).Marshal([]byte("token")); !errors.Is(err, encodingx.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+
+	encoding := encodingx.NewHMAC(encodingx.StaticKey(hmacKey))
+	if _, err := encoding.Marshal(42); !errors.Is(err, encodingx.ErrHMACWrongValueType) {
+		t.Errorf("expected ErrHMACWrongValueType, got %v", err)
+	}
+	data, _ := encoding.Marshal([]byte("token"))
+	var s string
+	if err := encoding.Unmarshal(data, &s); !errors.Is(err, encodingx.ErrHMACWrongValueType) {
+		t.Errorf("expected ErrHMACWrongValueType, got %v", err)
+	}
+}
+
+// TestHMACChain 测试签名令牌经过链式编码后的校验
+func TestHMACChain(t *testing.T) {
+	registry := encodingx.DefaultRegistry().NewChild()
+	registry.MustRegister(encodingx.NewHMAC(encodingx.StaticKey(hmacKey)))
+	chain, err := registry.ParseChain("JSON|HMAC|Base64URL")
+	if err != nil {
+		t.Fatalf("ParseChain failed: %v", err)
+	}
+
+	original := TestStruct{Integer: 21, String: "token", Bool: true}
+	data, err := chain.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var result TestStruct
+	if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+		t.Errorf("chain round trip failed: %v", err)
+	}
+
+	forged, err := encodingx.MustParseChain("JSON|Base64URL").Marshal(TestStruct{Integer: 21, String: "admin"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if err := chain.Unmarshal(forged, &result); !errors.Is(err, encodingx.ErrAuthentication) {
+		t.Errorf("expected ErrAuthentication, got %v", err)
+	}
+}
@@ -0,0 +1,363 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// JWT 紧凑序列化测试
+// ============================================================================
+
+// jwtTestClaims 是测试用的声明结构
+type jwtTestClaims struct {
+	Subject   string      `json:"sub"`
+	Issuer    string      `json:"iss,omitempty"`
+	Audience  interface{} `json:"aud,omitempty"`
+	ExpiresAt int64       `json:"exp,omitempty"`
+	NotBefore int64       `json:"nbf,omitempty"`
+	Admin     bool        `json:"admin"`
+}
+
+// jwtTestKey 是一种算法的私钥及其公钥
+type jwtTestKey struct {
+	alg     string
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+var jwtSecret = []byte("a 32 byte secret for HS256 tests")
+
+func newJWTTestKeys(t testing.TB) []jwtTestKey {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	return []jwtTestKey{
+		{encodingx.JWTAlgHS256, jwtSecret, jwtSecret},
+		{encodingx.JWTAlgRS256, rsaKey, &rsaKey.PublicKey},
+		{encodingx.JWTAlgES256, ecKey, &ecKey.PublicKey},
+		{encodingx.JWTAlgEdDSA, edPrivate, edPublic},
+	}
+}
+
+// TestJWTRoundTrip 测试四种算法的签名与验证
+func TestJWTRoundTrip(t *testing.T) {
+	claims := jwtTestClaims{Subject: "user-1", ExpiresAt: time.Now().Add(time.Hour).Unix(), Admin: true}
+	for _, key := range newJWTTestKeys(t) {
+		t.Run(key.alg, func(t *testing.T) {
+			signer := encodingx.NewJWT(nil, encodingx.WithSigningKey(key.alg, "k1", key.private))
+			verifier := encodingx.NewJWT(encodingx.PublicKeys{"k1": key.public})
+
+			token, err := signer.Marshal(claims)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			parts := strings.Split(string(token), ".")
+			if len(parts) != 3 {
+				t.Fatalf("expected 3 parts, got %d", len(parts))
+			}
+			header, _ := base64.RawURLEncoding.DecodeString(parts[0])
+			want := fmt.Sprintf(`{"alg":%q,"kid":"k1","typ":"JWT"}`, key.alg)
+			if string(header) != want {
+				t.Errorf("header %s, want %s", header, want)
+			}
+
+			for _, encoding := range []*encodingx.JWT{signer, verifier} {
+				var decoded jwtTestClaims
+				if err := encoding.Unmarshal(token, &decoded); err != nil {
+					t.Fatalf("Unmarshal failed: %v", err)
+				}
+				if decoded != claims {
+					t.Errorf("got %+v, want %+v", decoded, claims)
+				}
+			}
+			if _, err := verifier.Marshal(claims); !errors.Is(err, encodingx.ErrSignNoPrivateKey) {
+				t.Errorf("expected ErrSignNoPrivateKey, got %v", err)
+			}
+		})
+	}
+}
+
+// TestJWTRFC7515 测试 RFC 7515 附录 A.1 的 HS256 示例
+func TestJWTRFC7515(t *testing.T) {
+	token := []byte("eyJ0eXAiOiJKV1QiLA0KICJhbGciOiJIUzI1NiJ9" +
+		".eyJpc3MiOiJqb2UiLA0KICJleHAiOjEzMDA4MTkzODAsDQogImh0dHA6Ly9leGFtcGxlLmNvbS9pc19yb290Ijp0cnVlfQ" +
+		".dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	keys, err := encodingx.ParseJWKS([]byte(`{"keys":[{"kty":"oct",` +
+		`"k":"AyM1SysPpbyDfgZld3umj1qzKObwVMkoqQ-EstJQLr_T-1qS0gZH75aKtMN3Yj0iPS4hcgUuTwjAzZr1Z9CAow"}]}`))
+	if err != nil {
+		t.Fatalf("ParseJWKS failed: %v", err)
+	}
+
+	// 示例令牌已于 2011 年过期
+	var claims map[string]interface{}
+	if err := encodingx.NewJWT(keys).Unmarshal(token, &claims); !errors.Is(err, encodingx.ErrJWTExpired) {
+		t.Errorf("expected ErrJWTExpired, got %v", err)
+	}
+	lenient := encodingx.NewJWT(keys, encodingx.WithLeeway(50*365*24*time.Hour), encodingx.WithIssuer("joe"))
+	if err := lenient.Unmarshal(token, &claims); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if claims["iss"] != "joe" || claims["http://example.com/is_root"] != true {
+		t.Errorf("unexpected claims %v", claims)
+	}
+}
+
+// TestJWTTamper 测试篡改、算法替换与 alg none 被拒绝
+func TestJWTTamper(t *testing.T) {
+	for _, key := range newJWTTestKeys(t) {
+		t.Run(key.alg, func(t *testing.T) {
+			signer := encodingx.NewJWT(nil, encodingx.WithSigningKey(key.alg, "k1", key.private))
+			token, err := signer.Marshal(jwtTestClaims{Subject: "user-1"})
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			parts := strings.Split(string(token), ".")
+			var claims jwtTestClaims
+
+			// 提权后的声明
+			forged := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"user-1","admin":true}`))
+			tampered := parts[0] + "." + forged + "." + parts[2]
+			if err := signer.Unmarshal([]byte(tampered), &claims); !errors.Is(err, encodingx.ErrAuthentication) {
+				t.Errorf("forged claims: expected ErrAuthentication, got %v", err)
+			}
+
+			// 替换为其他算法
+			for _, alg := range []string{"HS256", "RS256", "ES256", "EdDSA"} {
+				if alg == key.alg {
+					continue
+				}
+				header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"` + alg + `","kid":"k1"}`))
+				swapped := header + "." + parts[1] + "." + parts[2]
+				if err := signer.Unmarshal([]byte(swapped), &claims); !errors.Is(err, encodingx.ErrAuthentication) {
+					t.Errorf("alg %s: expected ErrAuthentication, got %v", alg, err)
+				}
+			}
+
+			none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + "."
+			if err := signer.Unmarshal([]byte(none), &claims); !errors.Is(err, encodingx.ErrJWTUnsupportedAlgorithm) {
+				t.Errorf("alg none: expected ErrJWTUnsupportedAlgorithm, got %v", err)
+			}
+		})
+	}
+}
+
+// TestJWTMalformed 测试格式错误的令牌
+func TestJWTMalformed(t *testing.T) {
+	encoding := encodingx.NewJWT(encodingx.PublicKeys{"": jwtSecret})
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256"}`))
+	crit := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","crit":["exp"]}`))
+	for _, token := range []string{
+		"",
+		"a.b",
+		"a.b.c.d",
+		"!!.e30.",
+		header + ".e30=.",
+		crit + ".e30.",
+	} {
+		var claims jwtTestClaims
+		if err := encoding.Unmarshal([]byte(token), &claims); !errors.Is(err, encodingx.ErrJWTMalformed) {
+			t.Errorf("%q: expected ErrJWTMalformed, got %v", token, err)
+		}
+	}
+
+	signer := encodingx.NewJWT(nil, encodingx.WithSigningKey(encodingx.JWTAlgHS256, "", jwtSecret))
+	if _, err := signer.Marshal([]string{"not", "an", "object"}); !errors.Is(err, encodingx.ErrJWTWrongValueType) {
+		t.Errorf("expected ErrJWTWrongValueType, got %v", err)
+	}
+	unsupported := encodingx.NewJWT(nil, encodingx.WithSigningKey("HS512", "", jwtSecret))
+	if _, err := unsupported.Marshal(jwtTestClaims{}); !errors.Is(err, encodingx.ErrJWTUnsupportedAlgorithm) {
+		t.Errorf("expected ErrJWTUnsupportedAlgorithm, got %v", err)
+	}
+	mismatched := encodingx.NewJWT(nil, encodingx.WithSigningKey(encodingx.JWTAlgRS256, "", jwtSecret))
+	if _, err := mismatched.Marshal(jwtTestClaims{}); !errors.Is(err, encodingx.ErrSignInvalidKey) {
+		t.Errorf("expected ErrSignInvalidKey, got %v", err)
+	}
+}
+
+// TestJWTClaims 测试 exp、nbf、iss 与 aud 的校验
+func TestJWTClaims(t *testing.T) {
+	now := time.Now()
+	signer := encodingx.NewJWT(nil, encodingx.WithSigningKey(encodingx.JWTAlgHS256, "", jwtSecret))
+	keys := encodingx.PublicKeys{"": jwtSecret}
+	strict := encodingx.NewJWT(keys, encodingx.WithIssuer("auth"), encodingx.WithAudience("api"))
+	lenient := encodingx.NewJWT(keys, encodingx.WithLeeway(time.Minute))
+
+	cases := []struct {
+		name    string
+		claims  jwtTestClaims
+		strict  error
+		lenient error
+	}{
+		{"valid", jwtTestClaims{Issuer: "auth", Audience: "api", ExpiresAt: now.Add(time.Hour).Unix()}, nil, nil},
+		{"audience list", jwtTestClaims{Issuer: "auth", Audience: []string{"web", "api"}}, nil, nil},
+		{"expired", jwtTestClaims{Issuer: "auth", Audience: "api", ExpiresAt: now.Add(-30 * time.Second).Unix()}, encodingx.ErrJWTExpired, nil},
+		{"long expired", jwtTestClaims{ExpiresAt: now.Add(-time.Hour).Unix()}, encodingx.ErrJWTExpired, encodingx.ErrJWTExpired},
+		{"not yet valid", jwtTestClaims{Issuer: "auth", Audience: "api", NotBefore: now.Add(30 * time.Second).Unix()}, encodingx.ErrJWTNotYetValid, nil},
+		{"far future", jwtTestClaims{NotBefore: now.Add(time.Hour).Unix()}, encodingx.ErrJWTNotYetValid, encodingx.ErrJWTNotYetValid},
+		{"wrong issuer", jwtTestClaims{Issuer: "other", Audience: "api"}, encodingx.ErrJWTInvalidIssuer, nil},
+		{"missing issuer", jwtTestClaims{Audience: "api"}, encodingx.ErrJWTInvalidIssuer, nil},
+		{"wrong audience", jwtTestClaims{Issuer: "auth", Audience: []string{"web"}}, encodingx.ErrJWTInvalidAudience, nil},
+		{"missing audience", jwtTestClaims{Issuer: "auth"}, encodingx.ErrJWTInvalidAudience, nil},
+	}
+	for _, tc := range cases {
+		token, err := signer.Marshal(tc.claims)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", tc.name, err)
+		}
+		var claims jwtTestClaims
+		if err := strict.Unmarshal(token, &claims); !errors.Is(err, tc.strict) {
+			t.Errorf("%s: strict expected %v, got %v", tc.name, tc.strict, err)
+		}
+		if err := lenient.Unmarshal(token, &claims); !errors.Is(err, tc.lenient) {
+			t.Errorf("%s: lenient expected %v, got %v", tc.name, tc.lenient, err)
+		}
+	}
+}
+
+// TestJWTClaimsOutOfRange 测试超出时间范围的 exp 与 nbf 不会溢出
+func TestJWTClaimsOutOfRange(t *testing.T) {
+	signer := encodingx.NewJWT(nil, encodingx.WithSigningKey(encodingx.JWTAlgHS256, "", jwtSecret))
+	cases := []struct {
+		claims string
+		err    error
+	}{
+		{`{"nbf":1e300}`, encodingx.ErrJWTNotYetValid},
+		{`{"exp":1e300}`, nil},
+		{`{"nbf":-1e300}`, nil},
+		{`{"exp":-1e300}`, encodingx.ErrJWTExpired},
+		{`{"nbf":9223372036854775807}`, encodingx.ErrJWTNotYetValid},
+		{`{"exp":9223372036854775807}`, nil},
+	}
+	for _, tc := range cases {
+		token, err := signer.Marshal(json.RawMessage(tc.claims))
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", tc.claims, err)
+		}
+		var claims map[string]interface{}
+		if err := signer.Unmarshal(token, &claims); !errors.Is(err, tc.err) {
+			t.Errorf("%s: expected %v, got %v", tc.claims, tc.err, err)
+		}
+	}
+}
+
+// TestJWTJWKS 测试从本地 JWKS 文档解析密钥并验证令牌
+func TestJWTJWKS(t *testing.T) {
+	keys := newJWTTestKeys(t)
+	b64 := base64.RawURLEncoding.EncodeToString
+	var documentKeys []map[string]string
+	for _, key := range keys {
+		jwk := map[string]string{"kid": key.alg + "-key", "alg": key.alg, "use": "sig"}
+		switch public := key.public.(type) {
+		case []byte:
+			jwk["kty"], jwk["k"] = "oct", b64(public)
+		case *rsa.PublicKey:
+			jwk["kty"], jwk["n"], jwk["e"] = "RSA", b64(public.N.Bytes()), "AQAB"
+		case *ecdsa.PublicKey:
+			jwk["kty"], jwk["crv"] = "EC", "P-256"
+			jwk["x"], jwk["y"] = b64(public.X.FillBytes(make([]byte, 32))), b64(public.Y.FillBytes(make([]byte, 32)))
+		case ed25519.PublicKey:
+			jwk["kty"], jwk["crv"], jwk["x"] = "OKP", "Ed25519", b64(public)
+		}
+		documentKeys = append(documentKeys, jwk)
+	}
+	// 加密密钥和不支持的类型被跳过
+	documentKeys = append(documentKeys,
+		map[string]string{"kty": "RSA", "kid": "enc", "use": "enc", "n": "AQAB", "e": "AQAB"},
+		map[string]string{"kty": "EC", "kid": "p384", "crv": "P-384", "x": "AA", "y": "AA"},
+		map[string]string{"kty": "unknown", "kid": "future"},
+	)
+	document, _ := json.Marshal(map[string]interface{}{"keys": documentKeys})
+
+	jwks, err := encodingx.ParseJWKS(document)
+	if err != nil {
+		t.Fatalf("ParseJWKS failed: %v", err)
+	}
+	if jwks.Len() != len(keys) {
+		t.Errorf("expected %d keys, got %d", len(keys), jwks.Len())
+	}
+	verifier := encodingx.NewJWT(jwks)
+	for _, key := range keys {
+		signer := encodingx.NewJWT(nil, encodingx.WithSigningKey(key.alg, key.alg+"-key", key.private))
+		token, err := signer.Marshal(jwtTestClaims{Subject: key.alg})
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", key.alg, err)
+		}
+		var claims jwtTestClaims
+		if err := verifier.Unmarshal(token, &claims); err != nil || claims.Subject != key.alg {
+			t.Errorf("%s: Unmarshal failed: %v", key.alg, err)
+		}
+
+		// 没有 kid 时按算法选出唯一的密钥
+		anonymous := encodingx.NewJWT(nil, encodingx.WithSigningKey(key.alg, "", key.private))
+		token, _ = anonymous.Marshal(jwtTestClaims{Subject: key.alg})
+		if err := verifier.Unmarshal(token, &claims); err != nil {
+			t.Errorf("%s: Unmarshal without kid failed: %v", key.alg, err)
+		}
+
+		unknown := encodingx.NewJWT(nil, encodingx.WithSigningKey(key.alg, "unknown", key.private))
+		token, _ = unknown.Marshal(jwtTestClaims{Subject: key.alg})
+		err = verifier.Unmarshal(token, &claims)
+		if !errors.Is(err, encodingx.ErrAuthentication) || !errors.Is(err, encodingx.ErrKeyNotFound) {
+			t.Errorf("%s: unknown kid expected ErrAuthentication and ErrKeyNotFound, got %v", key.alg, err)
+		}
+	}
+}
+
+// TestJWKSInvalid 测试格式错误的 JWKS 文档
+func TestJWKSInvalid(t *testing.T) {
+	if _, err := encodingx.ParseJWKS([]byte("not json")); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	for _, key := range []string{
+		`{"kty":"oct","k":""}`,
+		`{"kty":"oct","k":"!!"}`,
+		`{"kty":"RSA","n":"","e":"AQAB"}`,
+		`{"kty":"OKP","crv":"Ed25519","x":"AAAA"}`,
+		// 不在曲线上的点
+		`{"kty":"EC","crv":"P-256","x":"` + strings.Repeat("A", 43) + `","y":"` + strings.Repeat("A", 42) + `E"}`,
+	} {
+		if _, err := encodingx.ParseJWKS([]byte(`{"keys":[` + key + `]}`)); !errors.Is(err, encodingx.ErrJWKSInvalidKey) {
+			t.Errorf("%s: expected ErrJWKSInvalidKey, got %v", key, err)
+		}
+	}
+
+	// 两把密钥都适用时，没有 kid 的令牌无法确定密钥
+	jwks, err := encodingx.ParseJWKS([]byte(`{"keys":[{"kty":"oct","kid":"a","k":"c2VjcmV0"},{"kty":"oct","kid":"b","k":"b3RoZXI"}]}`))
+	if err != nil {
+		t.Fatalf("ParseJWKS failed: %v", err)
+	}
+	if _, err := jwks.VerificationKey("", encodingx.JWTAlgHS256); !errors.Is(err, encodingx.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound, got %v", err)
+	}
+	if key, err := jwks.VerificationKey("b", encodingx.JWTAlgHS256); err != nil || !bytes.Equal(key.([]byte), []byte("other")) {
+		t.Errorf("VerificationKey failed: %v", err)
+	}
+	if _, err := jwks.VerificationKey("a", encodingx.JWTAlgRS256); !errors.Is(err, encodingx.ErrKeyNotFound) {
+		t.Errorf("expected ErrKeyNotFound for an unsuitable key, got %v", err)
+	}
+}
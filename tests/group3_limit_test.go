@@ -0,0 +1,219 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// 解压炸弹防护测试
+// ============================================================================
+
+// bombSize 超过默认上限 DefaultMaxDecompressedSize
+const bombSize = encodingx.DefaultMaxDecompressedSize + 1<<20
+
+// limitCodecs 返回所有支持流式解码的压缩编码，opts 传给每个构造函数，
+// fast 时除没有级别的 SnappyFramed 外都使用最快的级别
+func limitCodecs(fast bool, opts ...encodingx.LimitOption) []encodingx.StreamEncoding {
+	var levels []encodingx.LevelOption
+	if fast {
+		levels = append(levels, encodingx.WithLevel(1))
+	}
+	compress := append(asOptions[encodingx.CompressOption](levels), asOptions[encodingx.CompressOption](opts)...)
+	dictionary := append(asOptions[encodingx.DictionaryCompressOption](levels), asOptions[encodingx.DictionaryCompressOption](opts)...)
+	hex := append(asOptions[encodingx.HexZlibOption](levels), asOptions[encodingx.HexZlibOption](opts)...)
+	return []encodingx.StreamEncoding{
+		encodingx.NewGzip(compress...),
+		encodingx.NewDeflate(dictionary...),
+		encodingx.NewZlib(dictionary...),
+		encodingx.NewHexZlib(hex...),
+		encodingx.NewZstd(dictionary...),
+		encodingx.NewLZ4(compress...),
+		encodingx.NewSnappyFramed(asOptions[encodingx.DecompressOption](opts)...),
+		encodingx.NewBrotli(compress...),
+	}
+}
+
+// TestDecompressBombRejected 测试默认上限拒绝解压后过大的数据
+func TestDecompressBombRejected(t *testing.T) {
+	bomb := make([]byte, bombSize)
+	for _, encoding := range limitCodecs(true) {
+		t.Run(encoding.String(), func(t *testing.T) {
+			if _, ok := encoding.(*encodingx.Brotli); ok && testing.Short() {
+				t.Skip("brotli 压缩较慢")
+			}
+			data, err := encoding.Marshal(bomb)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			var decoded encodingx.Bytes
+			if err := encoding.Unmarshal(data, &decoded); !errors.Is(err, encodingx.ErrDecompressedTooLarge) {
+				t.Errorf("Unmarshal expected ErrDecompressedTooLarge, got %v", err)
+			}
+			_, err = io.ReadAll(encoding.NewDecoder(bytes.NewReader(data)).(io.Reader))
+			if !errors.Is(err, encodingx.ErrDecompressedTooLarge) {
+				t.Errorf("Decoder expected ErrDecompressedTooLarge, got %v", err)
+			}
+		})
+	}
+}
+
+// TestMaxDecompressedSize 测试 WithMaxDecompressedSize 的边界
+func TestMaxDecompressedSize(t *testing.T) {
+	size := int64(len(compressPayload))
+	for _, encoding := range limitCodecs(false) {
+		t.Run(encoding.String(), func(t *testing.T) {
+			data, err := encoding.Marshal(compressPayload)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			cases := []struct {
+				limit int64
+				ok    bool
+			}{
+				{size, true},
+				{size - 1, false},
+				{0, true},
+			}
+			for _, tc := range cases {
+				limited := limitCodec(t, encoding, encodingx.WithMaxDecompressedSize(tc.limit))
+
+				var decoded encodingx.Bytes
+				err := limited.Unmarshal(data, &decoded)
+				if tc.ok && (err != nil || !bytes.Equal(decoded.Data, compressPayload)) {
+					t.Errorf("limit %d: Unmarshal failed: %v", tc.limit, err)
+				}
+				if !tc.ok && !errors.Is(err, encodingx.ErrDecompressedTooLarge) {
+					t.Errorf("limit %d: expected ErrDecompressedTooLarge, got %v", tc.limit, err)
+				}
+
+				streamed, err := io.ReadAll(limited.NewDecoder(bytes.NewReader(data)).(io.Reader))
+				if tc.ok && (err != nil || !bytes.Equal(streamed, compressPayload)) {
+					t.Errorf("limit %d: Decoder failed: %v", tc.limit, err)
+				}
+				if !tc.ok && !errors.Is(err, encodingx.ErrDecompressedTooLarge) {
+					t.Errorf("limit %d: Decoder expected ErrDecompressedTooLarge, got %v", tc.limit, err)
+				}
+			}
+		})
+	}
+}
+
+// TestMaxRatio 测试 WithMaxRatio 按压缩比拒绝数据
+func TestMaxRatio(t *testing.T) {
+	for _, encoding := range limitCodecs(false) {
+		t.Run(encoding.String(), func(t *testing.T) {
+			data, err := encoding.Marshal(compressPayload)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			var decoded encodingx.Bytes
+			strict := limitCodec(t, encoding, encodingx.WithMaxRatio(2))
+			if err := strict.Unmarshal(data, &decoded); !errors.Is(err, encodingx.ErrDecompressedTooLarge) {
+				t.Errorf("expected ErrDecompressedTooLarge, got %v", err)
+			}
+			_, err = io.ReadAll(strict.NewDecoder(bytes.NewReader(data)).(io.Reader))
+			if !errors.Is(err, encodingx.ErrDecompressedTooLarge) {
+				t.Errorf("Decoder expected ErrDecompressedTooLarge, got %v", err)
+			}
+
+			loose := limitCodec(t, encoding, encodingx.WithMaxRatio(1000))
+			if err := loose.Unmarshal(data, &decoded); err != nil || !bytes.Equal(decoded.Data, compressPayload) {
+				t.Errorf("Unmarshal failed: %v", err)
+			}
+		})
+	}
+}
+
+// limitCodec 用 opts 重新构造与 encoding 同类的编码
+func limitCodec(t *testing.T, encoding encodingx.StreamEncoding, opts ...encodingx.LimitOption) encodingx.StreamEncoding {
+	t.Helper()
+	for _, codec := range limitCodecs(false, opts...) {
+		if codec.String() == encoding.String() {
+			return codec
+		}
+	}
+	t.Fatalf("unknown encoding %s", encoding)
+	return nil
+}
+
+// TestBlockDecompressLimits 测试块格式在分配前检查长度头
+func TestBlockDecompressLimits(t *testing.T) {
+	cases := []struct {
+		encoding encodingx.Encoding
+		limited  encodingx.Encoding
+	}{
+		{encodingx.NewLZ4Block(), encodingx.NewLZ4Block(encodingx.WithMaxDecompressedSize(100))},
+		{encodingx.NewSnappy(), encodingx.NewSnappy(encodingx.WithMaxDecompressedSize(100))},
+		{encodingx.NewLZ4Block(), encodingx.NewLZ4Block(encodingx.WithMaxRatio(2))},
+		{encodingx.NewSnappy(), encodingx.NewSnappy(encodingx.WithMaxRatio(2))},
+	}
+	for _, tc := range cases {
+		t.Run(tc.encoding.String(), func(t *testing.T) {
+			data, err := tc.encoding.Marshal(compressPayload)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var decoded encodingx.Bytes
+			if err := tc.limited.Unmarshal(data, &decoded); !errors.Is(err, encodingx.ErrDecompressedTooLarge) {
+				t.Errorf("expected ErrDecompressedTooLarge, got %v", err)
+			}
+			if err := tc.encoding.Unmarshal(data, &decoded); err != nil || !bytes.Equal(decoded.Data, compressPayload) {
+				t.Errorf("Unmarshal failed: %v", err)
+			}
+		})
+	}
+}
+
+// ============================================================================
+// 链式编码字节预算测试
+// ============================================================================
+
+// TestChainByteBudget 测试 WithByteBudget 限制各阶段之间传递的字节总数
+func TestChainByteBudget(t *testing.T) {
+	original := TestStruct{Integer: 19, String: strings.Repeat("message bus ", 100), Bool: true}
+	chain := encodingx.MustParseChain("JSON|Gzip|Base64")
+	data, err := chain.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	// Base64 与 Gzip 两个阶段的输出都计入预算
+	tight := chain.WithByteBudget(1000)
+	var decoded TestStruct
+	err = tight.Unmarshal(data, &decoded)
+	if !errors.Is(err, encodingx.ErrDecompressedTooLarge) {
+		t.Fatalf("expected ErrDecompressedTooLarge, got %v", err)
+	}
+	var chainErr *encodingx.ChainError
+	if !errors.As(err, &chainErr) {
+		t.Errorf("expected ChainError, got %T", err)
+	}
+	if err := tight.NewDecoder(bytes.NewReader(data)).Decode(&decoded); !errors.Is(err, encodingx.ErrDecompressedTooLarge) {
+		t.Errorf("Decoder expected ErrDecompressedTooLarge, got %v", err)
+	}
+
+	roomy := chain.WithByteBudget(4096)
+	if err := roomy.Unmarshal(data, &decoded); err != nil || !original.Equal(decoded) {
+		t.Errorf("Unmarshal failed: %v", err)
+	}
+	if err := roomy.NewDecoder(bytes.NewReader(data)).Decode(&decoded); err != nil || !original.Equal(decoded) {
+		t.Errorf("Decoder failed: %v", err)
+	}
+
+	// 预算随 Reverse 保留，且不影响原链
+	if err := roomy.Reverse().Reverse().Unmarshal(data, &decoded); err != nil {
+		t.Errorf("reversed Unmarshal failed: %v", err)
+	}
+	if err := chain.Unmarshal(data, &decoded); err != nil {
+		t.Errorf("unbudgeted Unmarshal failed: %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"errors"
+	"mime"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// QuotedPrintable / EncodedWord 编码器测试
+// ============================================================================
+
+// TestQuotedPrintable 测试 quoted-printable 编码输出与往返
+func TestQuotedPrintable(t *testing.T) {
+	enc := encodingx.NewQuotedPrintable()
+	data, err := enc.Marshal([]byte("héllo=world"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != "h=C3=A9llo=3Dworld" {
+		t.Errorf("unexpected output %q", data)
+	}
+
+	// 默认模式下换行被编码，往返不丢失字节
+	payload := []byte(strings.Repeat("line one\nline two\r\n", 10) + "\x00\xff")
+	data, err = enc.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	for _, line := range strings.Split(string(data), "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("line exceeds 76 columns: %q", line)
+		}
+	}
+	var result encodingx.Bytes
+	if err := enc.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, payload) {
+		t.Errorf("round trip failed: %q, %v", result.Data, err)
+	}
+
+	// 文本模式下换行成为硬换行，解码为 CRLF
+	text := encodingx.NewQuotedPrintable(encodingx.WithTextMode())
+	data, err = text.Marshal([]byte("a\nb"))
+	if err != nil || string(data) != "a\r\nb" {
+		t.Errorf("text mode output %q, %v", data, err)
+	}
+	if err := text.Unmarshal(data, &result); err != nil || string(result.Data) != "a\r\nb" {
+		t.Errorf("text mode decode %q, %v", result.Data, err)
+	}
+
+	if _, err := enc.Marshal(1); err != encodingx.ErrQuotedPrintableWrongValueType {
+		t.Errorf("expected ErrQuotedPrintableWrongValueType, got %v", err)
+	}
+
+	// 流式编码与 Marshal 一致
+	var buf bytes.Buffer
+	encoder := enc.NewEncoder(&buf)
+	if err := encoder.Encode(payload); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	expected, _ := enc.Marshal(payload)
+	if !bytes.Equal(buf.Bytes(), expected) {
+		t.Error("stream output differs from Marshal")
+	}
+}
+
+// TestEncodedWordForms 测试 B 和 Q 两种形式
+func TestEncodedWordForms(t *testing.T) {
+	cases := []struct {
+		encoding encodingx.Encoding
+		input    string
+		expected string
+	}{
+		{encodingx.NewEncodedWord(), "hello", "=?utf-8?B?aGVsbG8=?="},
+		{encodingx.NewEncodedWord(encodingx.WithQEncoding()), "café au_lait?", "=?utf-8?Q?caf=C3=A9_au=5Flait=3F?="},
+		{encodingx.NewEncodedWord(encodingx.WithQEncoding(), encodingx.WithCharset("iso-8859-1")), "caf\xe9", "=?iso-8859-1?Q?caf=E9?="},
+		{encodingx.NewEncodedWord(), "", ""},
+	}
+	for _, tc := range cases {
+		data, err := tc.encoding.Marshal([]byte(tc.input))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, data)
+		}
+		var result encodingx.Bytes
+		if err := tc.encoding.Unmarshal(data, &result); err != nil || string(result.Data) != tc.input {
+			t.Errorf("round trip of %q failed: %q, %v", tc.input, result.Data, err)
+		}
+	}
+}
+
+// TestEncodedWordSplit 测试长文本拆分为多个不超过 75 字符的编码字，且不拆分 UTF-8 字符
+func TestEncodedWordSplit(t *testing.T) {
+	input := strings.Repeat("编码测试 mixed text ", 12)
+	for _, enc := range []encodingx.Encoding{encodingx.NewEncodedWord(), encodingx.NewEncodedWord(encodingx.WithQEncoding())} {
+		data, err := enc.Marshal([]byte(input))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		words := strings.Split(string(data), " ")
+		if len(words) < 2 {
+			t.Errorf("expected several words, got %d", len(words))
+		}
+		dec := new(mime.WordDecoder)
+		for _, word := range words {
+			if len(word) > 75 {
+				t.Errorf("word exceeds 75 characters: %q", word)
+			}
+			decoded, err := dec.Decode(word)
+			if err != nil || !utf8.ValidString(decoded) {
+				t.Errorf("word %q does not hold whole characters: %v", word, err)
+			}
+		}
+		// 与标准库解码结果一致
+		header, err := dec.DecodeHeader(string(data))
+		if err != nil || header != input {
+			t.Errorf("mime.WordDecoder mismatch: %q, %v", header, err)
+		}
+		var result encodingx.Bytes
+		if err := enc.Unmarshal(data, &result); err != nil || string(result.Data) != input {
+			t.Errorf("round trip failed: %q, %v", result.Data, err)
+		}
+	}
+}
+
+// TestEncodedWordDecode 测试编码字之外的文本、大小写与错误
+func TestEncodedWordDecode(t *testing.T) {
+	enc := encodingx.NewEncodedWord()
+	var result encodingx.Bytes
+	input := "Re: =?UTF-8?q?caf=C3=A9?=  \r\n =?utf-8?b?IG9r?= (plain)"
+	if err := enc.Unmarshal([]byte(input), &result); err != nil || string(result.Data) != "Re: café ok (plain)" {
+		t.Errorf("decode failed: %q, %v", result.Data, err)
+	}
+	if err := enc.Unmarshal([]byte("=?us-ascii?Q?plain?="), &result); err != nil || string(result.Data) != "plain" {
+		t.Errorf("us-ascii decode failed: %q, %v", result.Data, err)
+	}
+
+	if err := enc.Unmarshal([]byte("=?iso-8859-1?Q?caf=E9?="), &result); !errors.Is(err, encodingx.ErrEncodedWordCharsetMismatch) {
+		t.Errorf("expected ErrEncodedWordCharsetMismatch, got %v", err)
+	}
+	for _, bad := range []string{"=?utf-8?B?***?=", "=?utf-8?Q?=Z1?=", "=?utf-8?X?abc?=", "=?utf-8?B?abc", "=?utf-8"} {
+		if err := enc.Unmarshal([]byte(bad), &result); !errors.Is(err, encodingx.ErrEncodedWordInvalidData) {
+			t.Errorf("decode %q: expected ErrEncodedWordInvalidData, got %v", bad, err)
+		}
+	}
+	if err := enc.Unmarshal(nil, new(string)); err != encodingx.ErrEncodedWordWrongValueType {
+		t.Errorf("expected ErrEncodedWordWrongValueType, got %v", err)
+	}
+}
+
+// TestMIMEEncodingsChain 测试在 JSON 和 XML 之后链式使用
+func TestMIMEEncodingsChain(t *testing.T) {
+	jsonOriginal := TestStruct{Integer: 2047, String: "héader = value"}
+	xmlOriginal := XMLTestStruct{Integer: 2045, String: "quoted\nprintable", Bool: true, Float: 1.5}
+	for _, name := range []string{"QuotedPrintable", "EncodedWord", "EncodedWordQ"} {
+		jsonChain, err := encodingx.ParseChain("JSON|" + name)
+		if err != nil {
+			t.Fatalf("ParseChain failed: %v", err)
+		}
+		data, err := jsonChain.Marshal(jsonOriginal)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", name, err)
+		}
+		var jsonResult TestStruct
+		if err := jsonChain.Unmarshal(data, &jsonResult); err != nil || !jsonOriginal.Equal(jsonResult) {
+			t.Errorf("JSON|%s round trip failed: %v", name, err)
+		}
+
+		xmlChain, err := encodingx.ParseChain("XML|" + name)
+		if err != nil {
+			t.Fatalf("ParseChain failed: %v", err)
+		}
+		data, err = xmlChain.Marshal(xmlOriginal)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", name, err)
+		}
+		var xmlResult XMLTestStruct
+		if err := xmlChain.Unmarshal(data, &xmlResult); err != nil || xmlResult != xmlOriginal {
+			t.Errorf("XML|%s round trip failed: %+v, %v", name, xmlResult, err)
+		}
+	}
+}
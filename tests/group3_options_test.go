@@ -0,0 +1,297 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================================================
+// 编码器选项测试
+// ============================================================================
+
+// TestOptionsZeroValue 测试不带选项的构造结果与零值行为一致
+func TestOptionsZeroValue(t *testing.T) {
+	original := NestedStruct{Name: "zero", Inner: TestStruct{Integer: 1, String: "<a&b>"}, Slice: []int{1, 2}}
+
+	expected, _ := json.Marshal(original)
+	for _, enc := range []encodingx.Encoding{encodingx.NewJSON(), encodingx.JSON{}} {
+		data, err := enc.Marshal(original)
+		if err != nil || !bytes.Equal(data, expected) {
+			t.Errorf("JSON output changed: %s, %v", data, err)
+		}
+		if enc.String() != "JSON" {
+			t.Errorf("expected name JSON, got %s", enc.String())
+		}
+	}
+
+	expected, _ = yaml.Marshal(original)
+	data, err := encodingx.NewYAML().Marshal(original)
+	if err != nil || !bytes.Equal(data, expected) {
+		t.Errorf("YAML output changed: %s, %v", data, err)
+	}
+
+	// 默认情况下忽略未知字段
+	var result TestStruct
+	if err := encodingx.NewJSON().Unmarshal([]byte(`{"integer":1,"unknown":2}`), &result); err != nil {
+		t.Errorf("unknown fields should be ignored by default: %v", err)
+	}
+}
+
+// TestOptionsIndent 测试 JSON、XML、YAML 的缩进选项
+func TestOptionsIndent(t *testing.T) {
+	original := NestedStruct{Name: "indent", Inner: TestStruct{Integer: 2}, Slice: []int{3}}
+
+	jsonEnc := encodingx.NewJSON(encodingx.WithIndent("  "))
+	data, err := jsonEnc.Marshal(original)
+	if err != nil {
+		t.Fatalf("JSON Marshal failed: %v", err)
+	}
+	expected, _ := json.MarshalIndent(original, "", "  ")
+	if !bytes.Equal(data, expected) {
+		t.Errorf("JSON indent mismatch:\n%s", data)
+	}
+	var decoded NestedStruct
+	if err := jsonEnc.Unmarshal(data, &decoded); err != nil || !original.Equal(decoded) {
+		t.Errorf("JSON indent round trip failed: %v", err)
+	}
+
+	xmlData, err := encodingx.NewXML(encodingx.WithPrefix("#"), encodingx.WithIndent("\t")).Marshal(original)
+	if err != nil {
+		t.Fatalf("XML Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(xmlData), "\n#\t<name>indent</name>") {
+		t.Errorf("XML indent mismatch:\n%s", xmlData)
+	}
+
+	yamlData, err := encodingx.NewYAML(encodingx.WithIndent("  ")).Marshal(original)
+	if err != nil {
+		t.Fatalf("YAML Marshal failed: %v", err)
+	}
+	if !strings.Contains(string(yamlData), "\n  integer: 2") {
+		t.Errorf("YAML indent mismatch:\n%s", yamlData)
+	}
+}
+
+// TestOptionsDisallowUnknownFields 测试严格模式拒绝未知字段
+func TestOptionsDisallowUnknownFields(t *testing.T) {
+	jsonEnc := encodingx.NewJSON(encodingx.WithDisallowUnknownFields())
+	var result TestStruct
+	if err := jsonEnc.Unmarshal([]byte(`{"integer":1,"unknown":2}`), &result); err == nil {
+		t.Error("expected error for unknown JSON field")
+	}
+	if err := jsonEnc.Unmarshal([]byte(`{"integer":1} {}`), &result); err != encodingx.ErrJSONTrailingData {
+		t.Errorf("expected ErrJSONTrailingData, got %v", err)
+	}
+	if err := jsonEnc.Unmarshal([]byte(" {\"integer\":1}\n"), &result); err != nil || result.Integer != 1 {
+		t.Errorf("strict JSON Unmarshal failed: %v", err)
+	}
+	if err := jsonEnc.NewDecoder(strings.NewReader(`{"unknown":2}`)).Decode(&result); err == nil {
+		t.Error("expected error for unknown JSON field in stream")
+	}
+
+	yamlEnc := encodingx.NewYAML(encodingx.WithDisallowUnknownFields())
+	if err := yamlEnc.Unmarshal([]byte("integer: 1\nunknown: 2\n"), &result); err == nil {
+		t.Error("expected error for unknown YAML field")
+	}
+	if err := yamlEnc.Unmarshal([]byte("integer: 3\n"), &result); err != nil || result.Integer != 3 {
+		t.Errorf("strict YAML Unmarshal failed: %v", err)
+	}
+}
+
+// TestOptionsDelimiter 测试 CSV 分隔符选项（TSV）
+func TestOptionsDelimiter(t *testing.T) {
+	records := []*CSVRecord{{ID: 1, Name: "a,b", Value: 1.5}, {ID: 2, Name: "c", Value: 2}}
+	for _, enc := range []encodingx.Encoding{
+		encodingx.NewCSV(encodingx.WithDelimiter('\t')),
+		encodingx.NewCSVWithHeaders(encodingx.WithDelimiter('\t')),
+	} {
+		data, err := enc.Marshal(records)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", enc, err)
+		}
+		if !strings.Contains(string(data), "1\ta,b\t1.5") {
+			t.Errorf("%s expected tab separated output, got %q", enc, data)
+		}
+		var decoded []*CSVRecord
+		if err := enc.Unmarshal(data, &decoded); err != nil || !CSVRecordsEqual(decoded, records) {
+			t.Errorf("%s round trip failed: %v", enc, err)
+		}
+	}
+}
+
+// TestOptionsLevel 测试 HexZlib 压缩级别选项
+func TestOptionsLevel(t *testing.T) {
+	payload := bytes.Repeat([]byte("compressible payload "), 200)
+	best, err := encodingx.NewHexZlib(encodingx.WithLevel(zlib.BestCompression)).Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	stored, err := encodingx.NewHexZlib(encodingx.WithLevel(zlib.NoCompression)).Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if len(stored) <= len(best) {
+		t.Errorf("expected stored output (%d) to exceed best compression (%d)", len(stored), len(best))
+	}
+
+	// 压缩级别不影响解码
+	for _, data := range [][]byte{best, stored} {
+		var result encodingx.Bytes
+		if err := encodingx.NewHexZlib().Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, payload) {
+			t.Errorf("decode failed: %v", err)
+		}
+	}
+
+	if _, err := encodingx.NewHexZlib(encodingx.WithLevel(42)).Marshal(payload); err == nil {
+		t.Error("expected error for invalid level")
+	}
+	encoder := encodingx.NewHexZlib(encodingx.WithLevel(42)).NewEncoder(new(bytes.Buffer))
+	if err := encoder.Encode(payload); err == nil {
+		t.Error("expected stream error for invalid level")
+	}
+}
+
+// TestOptionsWithName 测试以自定义名称注册配置后的实例并在链中使用
+func TestOptionsWithName(t *testing.T) {
+	pretty := encodingx.NewJSON(encodingx.WithName("PrettyJSON"), encodingx.WithIndent("  "))
+	if pretty.String() != "PrettyJSON" || pretty.Reverse().String() != "PrettyJSON" {
+		t.Errorf("expected name PrettyJSON, got %s", pretty.String())
+	}
+
+	registry := encodingx.DefaultRegistry().NewChild()
+	if err := registry.Register(pretty, encodingx.NewCSV(encodingx.WithName("TSV"), encodingx.WithDelimiter('\t'))); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := registry.Lookup("JSON"); err != nil {
+		t.Errorf("default JSON should stay available: %v", err)
+	}
+
+	chain, err := registry.ParseChain("PrettyJSON|Base64")
+	if err != nil {
+		t.Fatalf("ParseChain failed: %v", err)
+	}
+	original := TestStruct{Integer: 7, String: "named"}
+	data, err := chain.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var raw encodingx.Bytes
+	if err := encodingx.NewBase64().Unmarshal(data, &raw); err != nil {
+		t.Fatalf("Base64 Unmarshal failed: %v", err)
+	}
+	if !strings.Contains(string(raw.Data), "\n  \"integer\": 7") {
+		t.Errorf("expected indented JSON, got %s", raw.Data)
+	}
+	var result TestStruct
+	if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+		t.Errorf("chain round trip failed: %v", err)
+	}
+
+	if err := registry.Register(encodingx.NewJSON(encodingx.WithName("Pretty JSON"))); err == nil {
+		t.Error("expected error for invalid name")
+	}
+}
+
+// asOptions 把一组选项转换为编码族 T 的选项，opts 中的每个选项都须属于 T
+func asOptions[T, S any](opts []S) []T {
+	converted := make([]T, 0, len(opts))
+	for _, opt := range opts {
+		converted = append(converted, any(opt).(T))
+	}
+	return converted
+}
+
+// constructPanic 调用 construct，返回其 panic 的错误
+func constructPanic(construct func()) (err error) {
+	defer func() {
+		err, _ = recover().(error)
+	}()
+	construct()
+	return nil
+}
+
+// TestOptionsFamilies 测试每种选项只能传给用得上它的编码
+func TestOptionsFamilies(t *testing.T) {
+	dict := []byte("dictionary")
+	cases := []struct {
+		name   string
+		option any
+		family reflect.Type
+		want   bool
+	}{
+		{"JSON dictionary", encodingx.WithDictionary(dict), reflect.TypeFor[encodingx.JSONOption](), false},
+		{"XML unknown", encodingx.WithDisallowUnknownFields(), reflect.TypeFor[encodingx.XMLOption](), false},
+		{"HMAC indent", encodingx.WithIndent(" "), reflect.TypeFor[encodingx.HMACOption](), false},
+		{"Gzip dictionary", encodingx.WithDictionary(dict), reflect.TypeFor[encodingx.CompressOption](), false},
+		{"Snappy level", encodingx.WithLevel(1), reflect.TypeFor[encodingx.DecompressOption](), false},
+		{"HexTier level", encodingx.WithLevel(1), reflect.TypeFor[encodingx.HexTierOption](), false},
+		{"Base64 padding", encodingx.WithPadding(), reflect.TypeFor[encodingx.Base64Option](), false},
+		{"sign tier policy", encodingx.WithTierPolicy(encodingx.TierNone()), reflect.TypeFor[encodingx.Option](), false},
+		{"JSON indent", encodingx.WithIndent(" "), reflect.TypeFor[encodingx.JSONOption](), true},
+		{"YAML unknown", encodingx.WithDisallowUnknownFields(), reflect.TypeFor[encodingx.YAMLOption](), true},
+		{"HexZlib dictionary", encodingx.WithDictionary(dict), reflect.TypeFor[encodingx.HexZlibOption](), true},
+		{"HexZlib level", encodingx.WithLevel(1), reflect.TypeFor[encodingx.HexZlibOption](), true},
+		{"Snappy ratio", encodingx.WithMaxRatio(10), reflect.TypeFor[encodingx.DecompressOption](), true},
+		{"HexTierSealed tier policy", encodingx.WithTierPolicy(encodingx.TierNone()), reflect.TypeFor[encodingx.HexTierSealedOption](), true},
+	}
+	for _, tc := range cases {
+		if got := reflect.TypeOf(tc.option).Implements(tc.family); got != tc.want {
+			t.Errorf("%s: accepted = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestOptionsComparable 测试所有已注册编码及带选项的实例仍可用 == 比较
+func TestOptionsComparable(t *testing.T) {
+	var encodings []encodingx.Encoding
+	for _, name := range encodingx.Names() {
+		enc, err := encodingx.Lookup(name)
+		if err != nil {
+			t.Fatalf("Lookup %s failed: %v", name, err)
+		}
+		encodings = append(encodings, enc)
+	}
+	dict := []byte("dictionary")
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	encodings = append(encodings,
+		encodingx.NewDeflate(encodingx.WithDictionary(dict)),
+		encodingx.NewZstd(encodingx.WithDictionary(dict)),
+		encodingx.NewHexZlib(encodingx.WithDictionary(dict), encodingx.WithTierPolicy(encodingx.TierBuckets(64, 256))),
+		encodingx.NewHexTier(encodingx.WithTierPolicy(encodingx.TierBuckets(64, 256))),
+		encodingx.NewAESGCM(encodingx.StaticKey(make([]byte, 32)), encodingx.WithAssociatedData(dict)),
+		encodingx.NewJWT(nil, encodingx.WithSigningKey(encodingx.JWTAlgHS256, "", dict)),
+		encodingx.NewJWT(encodingx.PublicKeys{"ed": edKey.Public()}),
+		encodingx.NewEd25519Sign("ed", edKey),
+		encodingx.NewEd25519Verify(encodingx.PublicKeys{"ed": edKey.Public()}),
+		encodingx.NewECDSASign("ec", ecKey),
+		encodingx.NewECDSAVerify(encodingx.PublicKeys{"ec": &ecKey.PublicKey}),
+		encodingx.NewHexTierSealed(sealedSecret, encodingx.WithTierPolicy(encodingx.TierBuckets(64, 256))),
+	)
+	for _, enc := range encodings {
+		err := constructPanic(func() {
+			value := enc.Reverse()
+			if value != value.Reverse() {
+				t.Errorf("%s: Reverse is not equal to itself", enc)
+			}
+			seen := map[encodingx.Encoding]bool{value: true}
+			if !seen[value.Reverse()] {
+				t.Errorf("%s: not found as a map key", enc)
+			}
+		})
+		if err != nil {
+			t.Errorf("%s: comparing panicked: %v", enc, err)
+		}
+	}
+}
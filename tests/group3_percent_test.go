@@ -0,0 +1,115 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"net/url"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// PercentEncoding 编码器测试
+// ============================================================================
+
+// TestPercentEncodingModes 测试三种模式下保留的字符
+func TestPercentEncodingModes(t *testing.T) {
+	input := []byte("a b/c?d=e&f+g:h@i~j#k%l!m")
+	cases := []struct {
+		encoding encodingx.Encoding
+		expected string
+	}{
+		{encodingx.NewPercentEncoding(), "a%20b%2Fc%3Fd%3De%26f%2Bg%3Ah%40i~j%23k%25l%21m"},
+		{encodingx.NewPercentEncoding(encodingx.WithPercentMode(encodingx.PercentPath)), "a%20b/c%3Fd=e&f+g:h@i~j%23k%25l!m"},
+		{encodingx.NewPercentEncoding(encodingx.WithPercentMode(encodingx.PercentQuery)), "a%20b/c?d%3De%26f%2Bg:h@i~j%23k%25l!m"},
+	}
+	for _, tc := range cases {
+		data, err := tc.encoding.Marshal(input)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(data) != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, data)
+		}
+		var result encodingx.Bytes
+		if err := tc.encoding.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, input) {
+			t.Errorf("round trip failed: %q, %v", result.Data, err)
+		}
+	}
+}
+
+// TestPercentEncodingInvalidMode 测试构造时拒绝未定义的模式
+func TestPercentEncodingInvalidMode(t *testing.T) {
+	for _, mode := range []encodingx.PercentMode{-1, encodingx.PercentQuery + 1} {
+		err := constructPanic(func() { encodingx.NewPercentEncoding(encodingx.WithPercentMode(mode)) })
+		if !errors.Is(err, encodingx.ErrPercentEncodingInvalidMode) {
+			t.Errorf("mode %d: expected ErrPercentEncodingInvalidMode, got %v", mode, err)
+		}
+	}
+}
+
+// TestPercentEncodingInterop 测试组件模式的输出可被 net/url 解码
+func TestPercentEncodingInterop(t *testing.T) {
+	rng := rand.New(rand.NewSource(3986))
+	enc := encodingx.NewPercentEncoding()
+	for i := 0; i < 200; i++ {
+		payload := make([]byte, rng.Intn(40))
+		rng.Read(payload)
+		data, err := enc.Marshal(payload)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		unescaped, err := url.PathUnescape(string(data))
+		if err != nil || unescaped != string(payload) {
+			t.Fatalf("net/url cannot decode %q: %v", data, err)
+		}
+		var result encodingx.Bytes
+		if err := enc.Unmarshal(data, &result); err != nil || !bytes.Equal(result.Data, payload) {
+			t.Fatalf("round trip failed: %v", err)
+		}
+	}
+
+	// 小写十六进制和 '+' 按原样解码
+	var result encodingx.Bytes
+	if err := enc.Unmarshal([]byte("%e4%bd%a0+"), &result); err != nil || string(result.Data) != "你+" {
+		t.Errorf("decode failed: %q, %v", result.Data, err)
+	}
+}
+
+// TestPercentEncodingInvalid 测试非法转义与错误类型
+func TestPercentEncodingInvalid(t *testing.T) {
+	enc := encodingx.NewPercentEncoding()
+	var result encodingx.Bytes
+	for _, input := range []string{"%", "%4", "abc%zz", "%g0"} {
+		if err := enc.Unmarshal([]byte(input), &result); !errors.Is(err, encodingx.ErrPercentEncodingInvalidData) {
+			t.Errorf("decode %q: expected ErrPercentEncodingInvalidData, got %v", input, err)
+		}
+	}
+	if _, err := enc.Marshal(1); err != encodingx.ErrPercentEncodingWrongValueType {
+		t.Errorf("expected ErrPercentEncodingWrongValueType, got %v", err)
+	}
+	if encodingx.PercentQuery.String() != "query" {
+		t.Errorf("unexpected mode name %s", encodingx.PercentQuery)
+	}
+}
+
+// TestPercentEncodingChain 测试注册的百分号编码可在链中使用
+func TestPercentEncodingChain(t *testing.T) {
+	original := TestStruct{Integer: 1, String: "a b&c"}
+	for _, name := range []string{"PercentEncoding", "PercentEncodingPath", "PercentEncodingQuery"} {
+		chain, err := encodingx.ParseChain("JSON|" + name)
+		if err != nil {
+			t.Fatalf("ParseChain %s failed: %v", name, err)
+		}
+		data, err := chain.Marshal(original)
+		if err != nil {
+			t.Fatalf("%s Marshal failed: %v", name, err)
+		}
+		var result TestStruct
+		if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+			t.Errorf("%s chain round trip failed: %v", name, err)
+		}
+	}
+}
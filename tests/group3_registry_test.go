@@ -0,0 +1,321 @@
+package encodingx_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// 公开注册表 API 测试
+// ============================================================================
+
+// upperEncoding 是测试用的第三方字节编码器，将字节转换为大写
+type upperEncoding struct {
+	name string
+}
+
+func (u upperEncoding) String() string {
+	return u.name
+}
+
+func (upperEncoding) Style() encodingx.EncodingStyleType {
+	return encodingx.EncodingStyleBytes
+}
+
+func (upperEncoding) Marshal(v interface{}) ([]byte, error) {
+	var data []byte
+	switch v := v.(type) {
+	case []byte:
+		data = v
+	case encodingx.Bytes:
+		data = v.Data
+	case *encodingx.Bytes:
+		data = v.Data
+	default:
+		return nil, errors.New("upper converts on wrong type value")
+	}
+	out := make([]byte, len(data))
+	for i, b := range data {
+		if b >= 'a' && b <= 'z' {
+			b -= 'a' - 'A'
+		}
+		out[i] = b
+	}
+	return out, nil
+}
+
+func (upperEncoding) Unmarshal(data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *encodingx.Bytes:
+		v.Data = append([]byte(nil), data...)
+		return nil
+	default:
+		return errors.New("upper converts on wrong type value")
+	}
+}
+
+func (u upperEncoding) Reverse() encodingx.Encoding {
+	return u
+}
+
+// TestRegistryRegisterAndLookup 测试注册第三方编码器后可以查找并在链中使用
+func TestRegistryRegisterAndLookup(t *testing.T) {
+	enc := upperEncoding{name: "test.Upper"}
+	if err := encodingx.Register(enc); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	defer encodingx.Unregister("test.Upper")
+
+	found, err := encodingx.Lookup("test.Upper")
+	if err != nil {
+		t.Fatalf("Lookup failed: %v", err)
+	}
+	if found.String() != "test.Upper" {
+		t.Errorf("Lookup returned %s", found.String())
+	}
+
+	chain := encodingx.NewChainEncoding(
+		[]string{"JSON", "test.Upper"},
+		[]string{"test.Upper", "JSON"},
+	)
+	data, err := chain.Marshal(map[string]string{"k": "abc"})
+	if err != nil {
+		t.Fatalf("chain Marshal failed: %v", err)
+	}
+	if string(data) != `{"K":"ABC"}` {
+		t.Errorf("unexpected chain output %s", data)
+	}
+}
+
+// TestRegistryDuplicateName 测试重复名称被拒绝
+func TestRegistryDuplicateName(t *testing.T) {
+	err := encodingx.Register(upperEncoding{name: "JSON"})
+	if !errors.Is(err, encodingx.ErrEncodingDuplicateName) {
+		t.Errorf("expected ErrEncodingDuplicateName, got %v", err)
+	}
+
+	// 同一批次内重复也应被拒绝，且不注册任何编码器
+	err = encodingx.Register(upperEncoding{name: "test.Dup"}, upperEncoding{name: "test.Dup"})
+	if !errors.Is(err, encodingx.ErrEncodingDuplicateName) {
+		t.Errorf("expected ErrEncodingDuplicateName, got %v", err)
+	}
+	if _, err := encodingx.Lookup("test.Dup"); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("failed batch should not register anything, got %v", err)
+	}
+}
+
+// TestRegistryInvalidName 测试非法名称被拒绝
+func TestRegistryInvalidName(t *testing.T) {
+	for _, name := range []string{"", "1JSON", "JSON|Base64", "a:b", "with space", "[x]"} {
+		err := encodingx.Register(upperEncoding{name: name})
+		if !errors.Is(err, encodingx.ErrEncodingInvalidName) {
+			t.Errorf("name %q: expected ErrEncodingInvalidName, got %v", name, err)
+		}
+	}
+	if err := encodingx.Register(nil); !errors.Is(err, encodingx.ErrEncodingNilEncoding) {
+		t.Errorf("expected ErrEncodingNilEncoding, got %v", err)
+	}
+}
+
+// TestRegistryMustRegisterPanics 测试 MustRegister 在重复名称时 panic
+func TestRegistryMustRegisterPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustRegister should panic on duplicate name")
+		}
+	}()
+	encodingx.MustRegister(upperEncoding{name: "Base64"})
+}
+
+// TestRegistryAlias 测试别名解析到同一个编码器
+func TestRegistryAlias(t *testing.T) {
+	if err := encodingx.RegisterAlias("test.J", "JSON"); err != nil {
+		t.Fatalf("RegisterAlias failed: %v", err)
+	}
+	defer encodingx.Unregister("test.J")
+
+	found, err := encodingx.Lookup("test.J")
+	if err != nil {
+		t.Fatalf("Lookup alias failed: %v", err)
+	}
+	if found.String() != "JSON" {
+		t.Errorf("alias should resolve to JSON, got %s", found.String())
+	}
+
+	if err := encodingx.RegisterAlias("test.Missing", "NoSuchEncoding"); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected ErrEncodingMissingEncoding, got %v", err)
+	}
+	if err := encodingx.RegisterAlias("Base64", "JSON"); !errors.Is(err, encodingx.ErrEncodingDuplicateName) {
+		t.Errorf("expected ErrEncodingDuplicateName, got %v", err)
+	}
+}
+
+// TestRegistryNames 测试 Names 返回排序后的内置编码器名称
+func TestRegistryNames(t *testing.T) {
+	names := encodingx.Names()
+	if !sort.StringsAreSorted(names) {
+		t.Error("Names should be sorted")
+	}
+	want := map[string]bool{"JSON": false, "Base64": false, "HexZlib": false, "Lazy": false}
+	for _, name := range names {
+		if _, ok := want[name]; ok {
+			want[name] = true
+		}
+	}
+	for name, ok := range want {
+		if !ok {
+			t.Errorf("Names should contain %s", name)
+		}
+	}
+}
+
+// TestRegistryUnregister 测试注销编码器
+func TestRegistryUnregister(t *testing.T) {
+	if err := encodingx.Register(upperEncoding{name: "test.Gone"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := encodingx.Unregister("test.Gone"); err != nil {
+		t.Fatalf("Unregister failed: %v", err)
+	}
+	if _, err := encodingx.Lookup("test.Gone"); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected ErrEncodingMissingEncoding after Unregister, got %v", err)
+	}
+	if err := encodingx.Unregister("test.Gone"); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected ErrEncodingMissingEncoding, got %v", err)
+	}
+}
+
+// TestValidEncodingName 测试命名规则
+func TestValidEncodingName(t *testing.T) {
+	valid := []string{"JSON", "Base64URL", "acme.Packet", "my_codec2"}
+	invalid := []string{"", "2x", ".x", "_x", "a-b", "a|b", "a:b"}
+	for _, name := range valid {
+		if !encodingx.ValidEncodingName(name) {
+			t.Errorf("%q should be valid", name)
+		}
+	}
+	for _, name := range invalid {
+		if encodingx.ValidEncodingName(name) {
+			t.Errorf("%q should be invalid", name)
+		}
+	}
+}
+
+// ============================================================================
+// 实例级注册表测试
+// ============================================================================
+
+// TestRegistryIsolated 测试独立注册表不包含内置编码器
+func TestRegistryIsolated(t *testing.T) {
+	registry := encodingx.NewRegistry()
+	if _, err := registry.Lookup("JSON"); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("isolated registry should not resolve JSON, got %v", err)
+	}
+	if len(registry.Names()) != 0 {
+		t.Errorf("isolated registry should be empty, got %v", registry.Names())
+	}
+
+	if err := registry.Register(upperEncoding{name: "Upper"}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if _, err := encodingx.Lookup("Upper"); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("default registry should not see instance registrations, got %v", err)
+	}
+
+	chain := registry.NewChainEncoding([]string{"Upper"}, []string{"Upper"})
+	data, err := chain.Marshal([]byte("abc"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != "ABC" {
+		t.Errorf("expected ABC, got %s", data)
+	}
+
+	// 链只能解析自身注册表中的名称
+	_, err = registry.NewChainEncoding([]string{"JSON"}, []string{"JSON"}).Marshal(TestStruct{})
+	if !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected ErrEncodingMissingEncoding, got %v", err)
+	}
+}
+
+// TestRegistryChildFallback 测试子注册表回退到父注册表，并可覆盖父注册表中的名称
+func TestRegistryChildFallback(t *testing.T) {
+	tenantA := encodingx.DefaultRegistry().NewChild()
+	tenantB := encodingx.DefaultRegistry().NewChild()
+
+	if tenantA.Parent() != encodingx.DefaultRegistry() {
+		t.Error("Parent should return the default registry")
+	}
+	if err := tenantA.Register(upperEncoding{name: "Base64"}); err != nil {
+		t.Fatalf("child should be able to shadow parent name: %v", err)
+	}
+
+	chainA := tenantA.NewChainEncoding([]string{"JSON", "Base64"}, []string{"Base64", "JSON"})
+	chainB := tenantB.NewChainEncoding([]string{"JSON", "Base64"}, []string{"Base64", "JSON"})
+
+	dataA, err := chainA.Marshal(map[string]string{"k": "v"})
+	if err != nil {
+		t.Fatalf("tenant A Marshal failed: %v", err)
+	}
+	if string(dataA) != `{"K":"V"}` {
+		t.Errorf("tenant A should use its own Base64, got %s", dataA)
+	}
+	dataB, err := chainB.Marshal(map[string]string{"k": "v"})
+	if err != nil {
+		t.Fatalf("tenant B Marshal failed: %v", err)
+	}
+	if string(dataB) != "eyJrIjoidiJ9" {
+		t.Errorf("tenant B should use built-in Base64, got %s", dataB)
+	}
+
+	var result map[string]string
+	if err := chainB.Unmarshal(dataB, &result); err != nil || result["k"] != "v" {
+		t.Errorf("tenant B round trip failed: %v %v", result, err)
+	}
+
+	// Reverse 保留注册表
+	if chainA.Reverse().(encodingx.ChainEncoding).Registry() != tenantA {
+		t.Error("Reverse should keep the registry")
+	}
+
+	// Names 包含父注册表的名称
+	found := false
+	for _, name := range tenantA.Names() {
+		if name == "JSON" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("child Names should include parent names")
+	}
+
+	// 子注册表无法注销父注册表中的编码器
+	if err := tenantB.Unregister("JSON"); !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected ErrEncodingMissingEncoding, got %v", err)
+	}
+}
+
+// TestRegistryConcurrent 测试并发注册和查找
+func TestRegistryConcurrent(t *testing.T) {
+	registry := encodingx.DefaultRegistry().NewChild()
+	done := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		go func(i int) {
+			defer func() { done <- struct{}{} }()
+			name := "Upper" + string(rune('A'+i))
+			if err := registry.Register(upperEncoding{name: name}); err != nil {
+				t.Errorf("Register %s failed: %v", name, err)
+			}
+			if _, err := registry.Lookup("JSON"); err != nil {
+				t.Errorf("Lookup failed: %v", err)
+			}
+			_ = registry.Names()
+		}(i)
+	}
+	for i := 0; i < 8; i++ {
+		<-done
+	}
+}
@@ -0,0 +1,259 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// Ed25519Sign / ECDSASign 签名测试
+// ============================================================================
+
+// signPair 是一组签名器及其对应的公钥
+type signPair struct {
+	signer encodingx.Encoding
+	public crypto.PublicKey
+	size   int
+}
+
+func newSignPairs(t testing.TB, id string) []signPair {
+	t.Helper()
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	ecPrivate, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	return []signPair{
+		{encodingx.NewEd25519Sign(id, edPrivate), edPublic, ed25519.SignatureSize},
+		{encodingx.NewECDSASign(id, ecPrivate), &ecPrivate.PublicKey, 64},
+	}
+}
+
+// TestSignRoundTrip 测试签名器签名、验证器验证
+func TestSignRoundTrip(t *testing.T) {
+	for _, pair := range newSignPairs(t, "edge-1") {
+		t.Run(pair.signer.String(), func(t *testing.T) {
+			verifier := pair.signer.Reverse()
+			if verifier.String() != pair.signer.String() {
+				t.Errorf("verifier name %q differs from %q", verifier, pair.signer)
+			}
+			for _, plain := range [][]byte{nil, []byte("token"), compressPayload} {
+				data, err := pair.signer.Marshal(plain)
+				if err != nil {
+					t.Fatalf("Marshal failed: %v", err)
+				}
+				if len(data) != 1+len("edge-1")+len(plain)+pair.size {
+					t.Errorf("unexpected length %d for %d bytes", len(data), len(plain))
+				}
+				if !bytes.HasPrefix(data, append([]byte("\x06edge-1"), plain...)) {
+					t.Errorf("unexpected envelope % x", data[:7])
+				}
+
+				var decoded encodingx.Bytes
+				if err := verifier.Unmarshal(data, &decoded); err != nil || !bytes.Equal(decoded.Data, plain) {
+					t.Errorf("verifier Unmarshal failed: %v", err)
+				}
+				if err := pair.signer.Unmarshal(data, &decoded); err != nil || !bytes.Equal(decoded.Data, plain) {
+					t.Errorf("signer Unmarshal failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// TestSignVerifierCannotSign 测试验证器不持有私钥，无法签名
+func TestSignVerifierCannotSign(t *testing.T) {
+	for _, pair := range newSignPairs(t, "k") {
+		verifiers := []encodingx.Encoding{
+			pair.signer.Reverse(),
+			pair.signer.Reverse().Reverse(),
+		}
+		switch pair.public.(type) {
+		case ed25519.PublicKey:
+			verifiers = append(verifiers, encodingx.NewEd25519Verify(encodingx.PublicKeys{"k": pair.public}))
+		default:
+			verifiers = append(verifiers, encodingx.NewECDSAVerify(encodingx.PublicKeys{"k": pair.public}))
+		}
+
+		data, err := pair.signer.Marshal([]byte("token"))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		for _, verifier := range verifiers {
+			if _, err := verifier.Marshal([]byte("token")); !errors.Is(err, encodingx.ErrSignNoPrivateKey) {
+				t.Errorf("%s: expected ErrSignNoPrivateKey, got %v", verifier, err)
+			}
+			var decoded encodingx.Bytes
+			if err := verifier.Unmarshal(data, &decoded); err != nil || string(decoded.Data) != "token" {
+				t.Errorf("%s: Unmarshal failed: %v", verifier, err)
+			}
+		}
+	}
+}
+
+// TestSignInvalidKeys 测试缺失或错误的私钥返回错误而不是 panic
+func TestSignInvalidKeys(t *testing.T) {
+	_, edPrivate, _ := ed25519.GenerateKey(rand.Reader)
+	cases := []struct {
+		name   string
+		signer func() encodingx.Encoding
+		err    error
+	}{
+		{"nil Ed25519", func() encodingx.Encoding { return encodingx.NewEd25519Sign("k", nil) }, encodingx.ErrSignNoPrivateKey},
+		{"short Ed25519", func() encodingx.Encoding { return encodingx.NewEd25519Sign("k", edPrivate[:32]) }, encodingx.ErrSignInvalidKey},
+		{"nil ECDSA", func() encodingx.Encoding { return encodingx.NewECDSASign("k", nil) }, encodingx.ErrSignNoPrivateKey},
+		{"empty ECDSA", func() encodingx.Encoding { return encodingx.NewECDSASign("k", &ecdsa.PrivateKey{}) }, encodingx.ErrSignInvalidKey},
+		{"ECDSA without D", func() encodingx.Encoding {
+			return encodingx.NewECDSASign("k", &ecdsa.PrivateKey{PublicKey: ecdsa.PublicKey{Curve: elliptic.P256()}})
+		}, encodingx.ErrSignInvalidKey},
+	}
+	for _, tc := range cases {
+		err := constructPanic(func() {
+			signer := tc.signer()
+			if _, err := signer.Marshal([]byte("token")); !errors.Is(err, tc.err) {
+				t.Errorf("%s: expected %v, got %v", tc.name, tc.err, err)
+			}
+			var decoded encodingx.Bytes
+			if err := signer.Unmarshal(append([]byte("\x01k"), make([]byte, 80)...), &decoded); err == nil {
+				t.Errorf("%s: Unmarshal should fail", tc.name)
+			}
+		})
+		if err != nil {
+			t.Errorf("%s: panicked: %v", tc.name, err)
+		}
+	}
+}
+
+// TestSignTamper 测试任意字节被篡改或截断时返回 ErrAuthentication
+func TestSignTamper(t *testing.T) {
+	for _, pair := range newSignPairs(t, "k") {
+		t.Run(pair.signer.String(), func(t *testing.T) {
+			verifier := pair.signer.Reverse()
+			data, err := pair.signer.Marshal([]byte(`{"user":1,"admin":false}`))
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var decoded encodingx.Bytes
+			for i := range data {
+				tampered := bytes.Clone(data)
+				tampered[i] ^= 0x01
+				if err := verifier.Unmarshal(tampered, &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+					t.Errorf("byte %d: expected ErrAuthentication, got %v", i, err)
+				}
+			}
+			for _, n := range []int{0, 1, 2, len(data) - 1} {
+				if err := verifier.Unmarshal(data[:n], &decoded); !errors.Is(err, encodingx.ErrAuthentication) {
+					t.Errorf("truncated to %d: expected ErrAuthentication, got %v", n, err)
+				}
+			}
+		})
+	}
+}
+
+// TestSignKeyRotation 测试验证器按密钥 ID 选择公钥
+func TestSignKeyRotation(t *testing.T) {
+	_, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+	_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+	oldSigner := encodingx.NewEd25519Sign("2024", oldKey)
+	newSigner := encodingx.NewEd25519Sign("2025", newKey)
+	verifier := encodingx.NewEd25519Verify(encodingx.PublicKeys{
+		"2024": oldKey.Public(),
+		"2025": newKey.Public(),
+	})
+
+	var decoded encodingx.Bytes
+	for _, signer := range []*encodingx.Ed25519Sign{oldSigner, newSigner} {
+		data, err := signer.Marshal([]byte("token"))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if err := verifier.Unmarshal(data, &decoded); err != nil {
+			t.Errorf("Unmarshal failed: %v", err)
+		}
+	}
+
+	// 新签名器的验证器不认识旧密钥
+	old, _ := oldSigner.Marshal([]byte("token"))
+	err := newSigner.Reverse().Unmarshal(old, &decoded)
+	if !errors.Is(err, encodingx.ErrAuthentication) || !errors.Is(err, encodingx.ErrKeyNotFound) {
+		t.Errorf("expected ErrAuthentication and ErrKeyNotFound, got %v", err)
+	}
+
+	// 同一密钥 ID 下的错误密钥类型
+	mixed := encodingx.NewEd25519Verify(encodingx.PublicKeys{"2024": "not a key"})
+	if err := mixed.Unmarshal(old, &decoded); !errors.Is(err, encodingx.ErrSignInvalidKey) {
+		t.Errorf("expected ErrSignInvalidKey, got %v", err)
+	}
+}
+
+// TestECDSASignCompatible 测试 ECDSA 签名可用标准库验证，且拒绝非 P-256 密钥
+func TestECDSASignCompatible(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	data, err := encodingx.NewECDSASign("", key).Marshal([]byte("token"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	message, signature := data[:len(data)-64], data[len(data)-64:]
+	digest := sha256.Sum256(message)
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(&key.PublicKey, digest[:], r, s) {
+		t.Error("signature does not verify with crypto/ecdsa")
+	}
+
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if _, err := encodingx.NewECDSASign("", p384).Marshal([]byte("token")); !errors.Is(err, encodingx.ErrSignInvalidKey) {
+		t.Errorf("expected ErrSignInvalidKey, got %v", err)
+	}
+	var decoded encodingx.Bytes
+	verifier := encodingx.NewECDSAVerify(encodingx.PublicKeys{"": &p384.PublicKey})
+	if err := verifier.Unmarshal(data, &decoded); !errors.Is(err, encodingx.ErrSignInvalidKey) {
+		t.Errorf("expected ErrSignInvalidKey, got %v", err)
+	}
+}
+
+// TestSignChain 测试签名端与仅验证的边缘节点使用同名编码组成链
+func TestSignChain(t *testing.T) {
+	for _, pair := range newSignPairs(t, "k") {
+		origin := encodingx.DefaultRegistry().NewChild()
+		origin.MustRegister(pair.signer)
+		edge := encodingx.DefaultRegistry().NewChild()
+		edge.MustRegister(pair.signer.Reverse())
+
+		spec := "JSON|" + pair.signer.String() + "|Base64URL"
+		signing, err := origin.ParseChain(spec)
+		if err != nil {
+			t.Fatalf("ParseChain failed: %v", err)
+		}
+		verifying, err := edge.ParseChain(spec)
+		if err != nil {
+			t.Fatalf("ParseChain failed: %v", err)
+		}
+
+		original := TestStruct{Integer: 22, String: "edge", Bool: true}
+		data, err := signing.Marshal(original)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", pair.signer, err)
+		}
+		var result TestStruct
+		if err := verifying.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+			t.Errorf("%s: chain round trip failed: %v", pair.signer, err)
+		}
+		if _, err := verifying.Marshal(original); !errors.Is(err, encodingx.ErrSignNoPrivateKey) {
+			t.Errorf("%s: edge chain expected ErrSignNoPrivateKey, got %v", pair.signer, err)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// 流式 Encoder/Decoder 测试
+// ============================================================================
+
+// TestStreamStructEncodings 测试结构体编码器的流式往返，且与 Unmarshal 兼容
+func TestStreamStructEncodings(t *testing.T) {
+	encodings := []encodingx.StreamEncoding{
+		encodingx.NewJSON(),
+		encodingx.NewXML(),
+		encodingx.NewYAML(),
+		encodingx.NewMsgPack(),
+	}
+	original := TestStruct{Integer: 42, String: "stream", Bool: true, Float: 2.5}
+	for _, enc := range encodings {
+		t.Run(enc.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := enc.NewEncoder(&buf)
+			if err := encoder.Encode(original); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			var streamed TestStruct
+			if err := enc.NewDecoder(bytes.NewReader(buf.Bytes())).Decode(&streamed); err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !original.Equal(streamed) {
+				t.Errorf("stream round trip failed: %+v", streamed)
+			}
+
+			var unmarshaled TestStruct
+			if err := enc.Unmarshal(buf.Bytes(), &unmarshaled); err != nil {
+				t.Fatalf("Unmarshal of streamed data failed: %v", err)
+			}
+			if !original.Equal(unmarshaled) {
+				t.Errorf("Unmarshal of streamed data mismatch: %+v", unmarshaled)
+			}
+		})
+	}
+}
+
+// TestStreamCSV 测试 CSV 流式编码可以分批写入行
+func TestStreamCSV(t *testing.T) {
+	for _, enc := range []encodingx.StreamEncoding{encodingx.NewCSV(), encodingx.NewCSVWithHeaders()} {
+		t.Run(enc.String(), func(t *testing.T) {
+			first := []*CSVRecord{{ID: 1, Name: "a", Value: 1.5}}
+			second := []*CSVRecord{{ID: 2, Name: "b", Value: 2.5}, {ID: 3, Name: "c", Value: 3.5}}
+
+			var buf bytes.Buffer
+			encoder := enc.NewEncoder(&buf)
+			if err := encoder.Encode(first); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if err := encoder.Encode(second); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			var result []*CSVRecord
+			if err := enc.NewDecoder(&buf).Decode(&result); err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !CSVRecordsEqual(result, append(first, second...)) {
+				t.Errorf("unexpected records %v", result)
+			}
+		})
+	}
+}
+
+// TestStreamBytesEncodings 测试字节编码器的流式输出与 Marshal 一致
+func TestStreamBytesEncodings(t *testing.T) {
+	encodings := []encodingx.StreamEncoding{
+		encodingx.NewLazy(),
+		encodingx.NewBase64(),
+		encodingx.NewBase64URL(),
+		encodingx.NewHex(),
+		encodingx.NewHexZlib(),
+	}
+	input := bytes.Repeat([]byte("streaming payload \x00\xff "), 50)
+	for _, enc := range encodings {
+		t.Run(enc.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := enc.NewEncoder(&buf)
+			w, ok := encoder.(io.Writer)
+			if !ok {
+				t.Fatal("bytes encoder should implement io.Writer")
+			}
+			// 分多次写入
+			for i := 0; i < len(input); i += 7 {
+				end := i + 7
+				if end > len(input) {
+					end = len(input)
+				}
+				if _, err := w.Write(input[i:end]); err != nil {
+					t.Fatalf("Write failed: %v", err)
+				}
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			expected, err := enc.Marshal(input)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), expected) {
+				t.Errorf("stream output differs from Marshal output")
+			}
+
+			decoder := enc.NewDecoder(bytes.NewReader(expected))
+			r, ok := decoder.(io.Reader)
+			if !ok {
+				t.Fatal("bytes decoder should implement io.Reader")
+			}
+			decoded, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("Read failed: %v", err)
+			}
+			if !bytes.Equal(decoded, input) {
+				t.Errorf("stream decode mismatch")
+			}
+
+			var result encodingx.Bytes
+			if err := enc.NewDecoder(bytes.NewReader(expected)).Decode(&result); err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !bytes.Equal(result.Data, input) {
+				t.Errorf("Decode mismatch")
+			}
+		})
+	}
+}
+
+// TestStreamHexZlibInvalid 测试 HexZlib 流式解码拒绝非法帧
+func TestStreamHexZlibInvalid(t *testing.T) {
+	enc := encodingx.NewHexZlib()
+	valid, err := enc.Marshal([]byte("hello hello hello"))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	cases := map[string][]byte{
+		"truncated": valid[:len(valid)-2],
+		"short":     []byte("0000"),
+		"extra":     append(append([]byte{}, valid...), "00"...),
+	}
+	for name, data := range cases {
+		var result encodingx.Bytes
+		err := enc.NewDecoder(bytes.NewReader(data)).Decode(&result)
+		if !errors.Is(err, encodingx.ErrHexInvalidData) {
+			t.Errorf("%s: expected ErrHexInvalidData, got %v", name, err)
+		}
+	}
+}
+
+// TestStreamChain 测试 ChainEncoding 组合流式管道，包含不支持流式的 HexTier 回退
+func TestStreamChain(t *testing.T) {
+	specs := []string{
+		"JSON|Base64|Hex",
+		"JSON|HexZlib|Base64URL",
+		"JSON|HexTier|Base64",
+		"YAML|HexTierRand",
+		"MsgPack",
+	}
+	original := TestStruct{Integer: 9, String: strings.Repeat("chain ", 100), Bool: true, Float: 0.25}
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			chain := encodingx.MustParseChain(spec)
+			var buf bytes.Buffer
+			encoder := chain.NewEncoder(&buf)
+			if err := encoder.Encode(original); err != nil {
+				t.Fatalf("Encode failed: %v", err)
+			}
+			if err := encoder.Close(); err != nil {
+				t.Fatalf("Close failed: %v", err)
+			}
+
+			var streamed TestStruct
+			if err := chain.NewDecoder(bytes.NewReader(buf.Bytes())).Decode(&streamed); err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			if !original.Equal(streamed) {
+				t.Errorf("stream round trip failed")
+			}
+
+			// 流式输出可以被非流式 Unmarshal 解码
+			var unmarshaled TestStruct
+			if err := chain.Unmarshal(buf.Bytes(), &unmarshaled); err != nil {
+				t.Fatalf("Unmarshal of streamed data failed: %v", err)
+			}
+			if !original.Equal(unmarshaled) {
+				t.Errorf("Unmarshal of streamed data mismatch")
+			}
+
+			// 非流式 Marshal 输出可以被流式 Decoder 解码
+			data, err := chain.Marshal(original)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			var decoded TestStruct
+			if err := chain.NewDecoder(bytes.NewReader(data)).Decode(&decoded); err != nil {
+				t.Fatalf("Decode of marshaled data failed: %v", err)
+			}
+			if !original.Equal(decoded) {
+				t.Errorf("Decode of marshaled data mismatch")
+			}
+		})
+	}
+}
+
+// TestStreamChainErrors 测试流式管道的错误带有阶段信息
+func TestStreamChainErrors(t *testing.T) {
+	chain := encodingx.NewChainEncoding([]string{"JSON", "YAML"}, []string{"Base64", "Nope"})
+	err := chain.NewEncoder(io.Discard).Encode(TestStruct{})
+	var chainErr *encodingx.ChainError
+	if !errors.As(err, &chainErr) || chainErr.Stage != 1 || !errors.Is(err, encodingx.ErrWrongEncodingStyle) {
+		t.Errorf("expected style error at encoder stage 1, got %v", err)
+	}
+	err = chain.NewDecoder(strings.NewReader("")).Decode(&TestStruct{})
+	if !errors.As(err, &chainErr) || chainErr.Stage != 1 || !errors.Is(err, encodingx.ErrEncodingMissingEncoding) {
+		t.Errorf("expected missing encoding at decoder stage 1, got %v", err)
+	}
+
+	chain = encodingx.MustParseChain("JSON|Base64|Hex")
+	var result TestStruct
+	err = chain.NewDecoder(strings.NewReader("zz")).Decode(&result)
+	if !errors.As(err, &chainErr) || chainErr.Stage != 0 || chainErr.Name != "Hex" {
+		t.Errorf("expected error at decoder stage 0 Hex, got %v", err)
+	}
+	err = chain.NewDecoder(strings.NewReader("2a2a")).Decode(&result)
+	if !errors.As(err, &chainErr) || chainErr.Stage != 1 || chainErr.Name != "Base64" {
+		t.Errorf("expected error at decoder stage 1 Base64, got %v", err)
+	}
+}
@@ -0,0 +1,245 @@
+package encodingx_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aura-studio/encodingx"
+)
+
+// ============================================================================
+// 分级策略测试
+// ============================================================================
+
+// TestTierPolicies 测试各策略的分级大小与校验
+func TestTierPolicies(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy encodingx.TierPolicy
+		sizes  map[int]int
+		valid  []int
+		bad    []int
+	}{
+		{
+			name:   "power of two",
+			policy: encodingx.TierPowerOfTwo(),
+			sizes:  map[int]int{1: 8, 8: 8, 9: 16, 524: 1024},
+			valid:  []int{8, 16, 1024},
+			bad:    []int{0, 4, 12, 524},
+		},
+		{
+			name:   "buckets",
+			policy: encodingx.TierBuckets(4096, 64, 1024, 256, 256, 0, -1),
+			sizes:  map[int]int{1: 64, 64: 64, 65: 256, 524: 1024, 4097: 8192, 9000: 12288},
+			valid:  []int{64, 256, 1024, 4096, 8192, 12288},
+			bad:    []int{0, 32, 128, 2048, 5000},
+		},
+		{
+			name:   "linear",
+			policy: encodingx.TierLinear(64),
+			sizes:  map[int]int{1: 64, 64: 64, 65: 128, 524: 576},
+			valid:  []int{64, 128, 576},
+			bad:    []int{0, 32, 100},
+		},
+		{
+			name:   "none",
+			policy: encodingx.TierNone(),
+			sizes:  map[int]int{1: 1, 524: 524},
+			valid:  []int{1, 5, 524},
+		},
+		{
+			name:   "empty buckets",
+			policy: encodingx.TierBuckets(0),
+			sizes:  map[int]int{7: 7},
+			valid:  []int{7},
+		},
+		{
+			name:   "linear step 1",
+			policy: encodingx.TierLinear(1),
+			sizes:  map[int]int{7: 7},
+			valid:  []int{7},
+		},
+	}
+	for _, tc := range cases {
+		for size, want := range tc.sizes {
+			if got := tc.policy.TierSize(size); got != want {
+				t.Errorf("%s: TierSize(%d) = %d, want %d", tc.name, size, got, want)
+			}
+		}
+		for _, size := range tc.valid {
+			if !tc.policy.IsTierSize(size) {
+				t.Errorf("%s: IsTierSize(%d) = false", tc.name, size)
+			}
+		}
+		for _, size := range tc.bad {
+			if tc.policy.IsTierSize(size) {
+				t.Errorf("%s: IsTierSize(%d) = true", tc.name, size)
+			}
+		}
+	}
+}
+
+// tierCodecs 返回所有分级编码，opts 传给每个构造函数
+func tierCodecs(opts ...encodingx.TierPolicyOption) []encodingx.Encoding {
+	tier := asOptions[encodingx.HexTierOption](opts)
+	return []encodingx.Encoding{
+		encodingx.NewHexTier(tier...),
+		encodingx.NewHexTierRand(tier...),
+		encodingx.NewHexZlib(asOptions[encodingx.HexZlibOption](opts)...),
+		encodingx.NewHexTierSealed(sealedSecret, asOptions[encodingx.HexTierSealedOption](opts)...),
+	}
+}
+
+// TestTierPolicyEncodings 测试各编码按策略填充并往返
+func TestTierPolicyEncodings(t *testing.T) {
+	payload := []byte(strings.Repeat("u", 520))
+	policies := map[string]encodingx.TierPolicy{
+		"buckets": encodingx.TierBuckets(64, 256, 1024, 4096),
+		"linear":  encodingx.TierLinear(48),
+		"none":    encodingx.TierNone(),
+	}
+	for name, policy := range policies {
+		for _, encoding := range tierCodecs(encodingx.WithTierPolicy(policy)) {
+			for _, input := range [][]byte{nil, []byte("hi"), payload} {
+				encoded, err := encoding.Marshal(input)
+				if err != nil {
+					t.Fatalf("%s/%s: Marshal failed: %v", name, encoding, err)
+				}
+				if size := len(encoded) / 2; !policy.IsTierSize(size) {
+					t.Errorf("%s/%s: %d bytes is not a tier", name, encoding, size)
+				}
+				var result encodingx.Bytes
+				if err := encoding.Unmarshal(encoded, &result); err != nil || !bytes.Equal(result.Data, input) {
+					t.Errorf("%s/%s: round trip failed: %v", name, encoding, err)
+				}
+			}
+		}
+	}
+}
+
+// TestTierPolicyOverhead 测试 520 字节的载荷不再填充到 1024 字节
+func TestTierPolicyOverhead(t *testing.T) {
+	payload := []byte(strings.Repeat("u", 520))
+	cases := []struct {
+		policy encodingx.TierPolicy
+		size   int
+	}{
+		{encodingx.TierPowerOfTwo(), 1024},
+		{encodingx.TierLinear(64), 576},
+		{encodingx.TierNone(), 524},
+	}
+	for _, tc := range cases {
+		encoded, err := encodingx.NewHexTier(encodingx.WithTierPolicy(tc.policy)).Marshal(payload)
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if len(encoded) != tc.size*2 {
+			t.Errorf("got %d bytes, want %d", len(encoded)/2, tc.size)
+		}
+	}
+}
+
+// TestTierPolicyDefaults 测试默认策略与原有格式一致
+func TestTierPolicyDefaults(t *testing.T) {
+	explicit := encodingx.NewHexTier(encodingx.WithTierPolicy(encodingx.TierPowerOfTwo()))
+	for _, size := range []int{0, 4, 5, 100} {
+		input := []byte(strings.Repeat("d", size))
+		want, _ := encodingx.NewHexTier().Marshal(input)
+		got, _ := explicit.Marshal(input)
+		if !bytes.Equal(got, want) {
+			t.Errorf("size %d: explicit power of two differs from the default", size)
+		}
+	}
+	// HexTierRand 默认最小 16 字节
+	encoded, _ := encodingx.NewHexTierRand().Marshal([]byte{})
+	if len(encoded) != 32 {
+		t.Errorf("HexTierRand: got %d bytes, want 16", len(encoded)/2)
+	}
+}
+
+// TestTierPolicyMismatch 测试 Unmarshal 拒绝其他策略产生的帧
+func TestTierPolicyMismatch(t *testing.T) {
+	payload := []byte(strings.Repeat("m", 100))
+	linear := encodingx.WithTierPolicy(encodingx.TierLinear(48))
+	buckets := encodingx.WithTierPolicy(encodingx.TierBuckets(64, 256, 1024))
+	writers := tierCodecs(linear)
+	readers := tierCodecs()
+	others := tierCodecs(buckets)
+	for i := range writers {
+		encoded, err := writers[i].Marshal(payload)
+		if err != nil {
+			t.Fatalf("%s: Marshal failed: %v", writers[i], err)
+		}
+		var result encodingx.Bytes
+		if err := readers[i].Unmarshal(encoded, &result); !errors.Is(err, encodingx.ErrHexInvalidData) {
+			t.Errorf("%s: default policy expected ErrHexInvalidData, got %v", writers[i], err)
+		}
+		if err := others[i].Unmarshal(encoded, &result); !errors.Is(err, encodingx.ErrHexInvalidData) {
+			t.Errorf("%s: bucket policy expected ErrHexInvalidData, got %v", writers[i], err)
+		}
+	}
+}
+
+// TestTierPolicyHexZlibStream 测试 HexZlib 流式编解码遵循策略
+func TestTierPolicyHexZlibStream(t *testing.T) {
+	policy := encodingx.TierLinear(48)
+	encoding := encodingx.NewHexZlib(encodingx.WithTierPolicy(policy))
+
+	var buf bytes.Buffer
+	encoder := encoding.NewEncoder(&buf)
+	if err := encoder.Encode(compressPayload); err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if !policy.IsTierSize(buf.Len() / 2) {
+		t.Errorf("%d bytes is not a tier", buf.Len()/2)
+	}
+	encoded := bytes.Clone(buf.Bytes())
+
+	decoded, err := io.ReadAll(encoding.NewDecoder(bytes.NewReader(encoded)).(io.Reader))
+	if err != nil || !bytes.Equal(decoded, compressPayload) {
+		t.Errorf("stream round trip failed: %v", err)
+	}
+	_, err = io.ReadAll(encodingx.NewHexZlib().NewDecoder(bytes.NewReader(encoded)).(io.Reader))
+	if !errors.Is(err, encodingx.ErrHexInvalidData) {
+		t.Errorf("default policy expected ErrHexInvalidData, got %v", err)
+	}
+}
+
+// fixedTier 是测试用的自定义策略：所有帧固定为 256 字节
+type fixedTier struct{}
+
+func (fixedTier) TierSize(size int) int {
+	return max(size, 256)
+}
+
+func (fixedTier) IsTierSize(size int) bool {
+	return size >= 256
+}
+
+// TestTierPolicyCustom 测试自定义策略与注册后在链中的使用
+func TestTierPolicyCustom(t *testing.T) {
+	registry := encodingx.DefaultRegistry().NewChild()
+	registry.MustRegister(encodingx.NewHexTier(encodingx.WithName("acme.HexTier256"), encodingx.WithTierPolicy(fixedTier{})))
+	chain, err := registry.ParseChain("JSON|acme.HexTier256")
+	if err != nil {
+		t.Fatalf("ParseChain failed: %v", err)
+	}
+	original := TestStruct{Integer: 25, String: "tier", Bool: true}
+	data, err := chain.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if len(data) != 512 {
+		t.Errorf("got %d bytes, want 256", len(data)/2)
+	}
+	var result TestStruct
+	if err := chain.Unmarshal(data, &result); err != nil || !original.Equal(result) {
+		t.Errorf("chain round trip failed: %v", err)
+	}
+}
@@ -0,0 +1,111 @@
+package encodingx
+
+import "slices"
+
+// TierPolicy decides the sizes the tier encodings, HexTier, HexTierRand,
+// HexZlib and HexTierSealed, pad their frames to, so that the output only
+// tells which tier a payload falls in. Sizes count frame bytes, before hex
+// encoding doubles them. Unmarshal rejects frames of a size the policy does
+// not produce, so both ends need the same policy.
+type TierPolicy interface {
+	// TierSize returns the size to pad a frame of size bytes to, which is
+	// at least size.
+	TierSize(size int) int
+	// IsTierSize reports whether TierSize can return size.
+	IsTierSize(size int) bool
+}
+
+// TierPowerOfTwo pads frames to a power of two, from 8 bytes up. It is the
+// default policy; HexTierRand starts at 16 bytes by default.
+func TierPowerOfTwo() TierPolicy {
+	return powerOfTwoTiers{min: 8}
+}
+
+// TierBuckets pads frames to the smallest of sizes that holds them, as in
+// TierBuckets(64, 256, 1024, 4096). Frames larger than the largest bucket
+// are padded to a multiple of it. Sizes of 0 or less are ignored; without
+// any other, frames are not padded.
+func TierBuckets(sizes ...int) TierPolicy {
+	buckets := slices.DeleteFunc(slices.Clone(sizes), func(size int) bool {
+		return size <= 0
+	})
+	if len(buckets) == 0 {
+		return noTiers{}
+	}
+	slices.Sort(buckets)
+	return bucketTiers{sizes: slices.Compact(buckets)}
+}
+
+// TierLinear pads frames to a multiple of step. A step of 1 or less does
+// not pad.
+func TierLinear(step int) TierPolicy {
+	if step <= 1 {
+		return noTiers{}
+	}
+	return linearTiers{step: step}
+}
+
+// TierNone does not pad frames, which leaves their size plain to see.
+func TierNone() TierPolicy {
+	return noTiers{}
+}
+
+type powerOfTwoTiers struct {
+	min int
+}
+
+func (p powerOfTwoTiers) TierSize(size int) int {
+	tierSize := p.min
+	for tierSize < size {
+		tierSize *= 2
+	}
+	return tierSize
+}
+
+func (p powerOfTwoTiers) IsTierSize(size int) bool {
+	return size >= p.min && size&(size-1) == 0
+}
+
+type bucketTiers struct {
+	sizes []int
+}
+
+func (b bucketTiers) TierSize(size int) int {
+	for _, bucket := range b.sizes {
+		if bucket >= size {
+			return bucket
+		}
+	}
+	largest := b.sizes[len(b.sizes)-1]
+	return (size + largest - 1) / largest * largest
+}
+
+func (b bucketTiers) IsTierSize(size int) bool {
+	if _, found := slices.BinarySearch(b.sizes, size); found {
+		return true
+	}
+	largest := b.sizes[len(b.sizes)-1]
+	return size > largest && size%largest == 0
+}
+
+type linearTiers struct {
+	step int
+}
+
+func (l linearTiers) TierSize(size int) int {
+	return max(l.step, (size+l.step-1)/l.step*l.step)
+}
+
+func (l linearTiers) IsTierSize(size int) bool {
+	return size > 0 && size%l.step == 0
+}
+
+type noTiers struct{}
+
+func (noTiers) TierSize(size int) int {
+	return size
+}
+
+func (noTiers) IsTierSize(int) bool {
+	return true
+}
@@ -1,6 +1,8 @@
 package encodingx
 
 import (
+	"context"
+
 	"github.com/aura-studio/reflectx"
 	"github.com/pelletier/go-toml/v2"
 )
@@ -39,6 +41,34 @@ func (TOML) Unmarshal(data []byte, v interface{}) error {
 	return toml.Unmarshal(data, v)
 }
 
+// MarshalContext is like Marshal but fails with ctx.Err() if ctx is done.
+// The TOML encoder cannot be interrupted, so ctx is only checked before
+// and after it: the whole value is encoded even if ctx is done meanwhile.
+func (t TOML) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	data, err := t.Marshal(v)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return data, err
+}
+
+// UnmarshalContext is like Unmarshal but fails with ctx.Err() if ctx is done.
+// Unlike YAML and CSV, the TOML decoder cannot be interrupted: it parses
+// data in one call, so ctx is only checked before and after it, and a
+// cancelled decode of a large document still runs to its end.
+func (t TOML) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := t.Unmarshal(data, v); err != nil {
+		return contextError(ctx, err)
+	}
+	return ctx.Err()
+}
+
 // Reverse returns the encoder itself since TOML is symmetric
 // (the same encoder is used for both serialization and deserialization).
 func (t TOML) Reverse() Encoding {
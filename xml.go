@@ -2,29 +2,38 @@ package encodingx
 
 import (
 	"encoding/xml"
+	"io"
 
 	"github.com/aura-studio/reflectx"
 )
 
-type XML struct{}
+type XML struct {
+	options
+}
 
 func init() {
 	register(new(XML))
 }
 
-func NewXML() *XML {
-	return new(XML)
+// NewXML creates an XML encoding, honoring WithName, WithIndent and WithPrefix.
+func NewXML(opts ...XMLOption) *XML {
+	return &XML{
+		options: newOptions(opts, XMLOption.applyXML),
+	}
 }
 
 func (xml XML) String() string {
-	return reflectx.TypeName(xml)
+	return xml.nameOr(reflectx.TypeName(xml))
 }
 
 func (xml XML) Style() EncodingStyleType {
 	return EncodingStyleStruct
 }
 
-func (XML) Marshal(v interface{}) ([]byte, error) {
+func (x XML) Marshal(v interface{}) ([]byte, error) {
+	if x.indented() {
+		return xml.MarshalIndent(v, x.prefix, x.indent)
+	}
 	return xml.Marshal(v)
 }
 
@@ -32,6 +41,19 @@ func (XML) Unmarshal(data []byte, v interface{}) error {
 	return xml.Unmarshal(data, v)
 }
 
+func (x XML) NewEncoder(w io.Writer) Encoder {
+	encoder := xml.NewEncoder(w)
+	encoder.Indent(x.prefix, x.indent)
+	return streamEncoder{
+		encode: encoder.Encode,
+		close:  encoder.Close,
+	}
+}
+
+func (XML) NewDecoder(r io.Reader) Decoder {
+	return xml.NewDecoder(r)
+}
+
 func (xml XML) Reverse() Encoding {
 	return xml
 }
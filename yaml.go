@@ -1,35 +1,97 @@
 package encodingx
 
 import (
+	"bytes"
+	"context"
+	"io"
+
 	"github.com/aura-studio/reflectx"
 
 	"gopkg.in/yaml.v3"
 )
 
-type YAML struct{}
+type YAML struct {
+	options
+}
 
 func init() {
 	register(new(YAML))
 }
 
-func NewYAML() *YAML {
-	return new(YAML)
+// NewYAML creates a YAML encoding, honoring WithName, WithIndent and
+// WithDisallowUnknownFields.
+func NewYAML(opts ...YAMLOption) *YAML {
+	return &YAML{
+		options: newOptions(opts, YAMLOption.applyYAML),
+	}
 }
 
 func (yaml YAML) String() string {
-	return reflectx.TypeName(yaml)
+	return yaml.nameOr(reflectx.TypeName(yaml))
 }
 
 func (yaml YAML) Style() EncodingStyleType {
 	return EncodingStyleStruct
 }
 
-func (YAML) Marshal(v interface{}) ([]byte, error) {
-	return yaml.Marshal(v)
+func (y YAML) Marshal(v interface{}) ([]byte, error) {
+	if y.indent == "" {
+		return yaml.Marshal(v)
+	}
+	return y.MarshalContext(context.Background(), v)
+}
+
+func (y YAML) Unmarshal(data []byte, v interface{}) error {
+	if !y.disallowUnknownFields {
+		return yaml.Unmarshal(data, v)
+	}
+	return y.UnmarshalContext(context.Background(), data, v)
+}
+
+// MarshalContext is like Marshal but stops emitting once ctx is done.
+func (y YAML) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := y.newEncoder(newContextWriter(ctx, &buf))
+	if err := encoder.Encode(v); err != nil {
+		return nil, contextError(ctx, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, contextError(ctx, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalContext is like Unmarshal but stops parsing once ctx is done.
+func (y YAML) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	err := y.newDecoder(newContextReader(ctx, bytes.NewReader(data))).Decode(v)
+	if err == io.EOF {
+		// An empty document, which Unmarshal accepts
+		return ctx.Err()
+	}
+	return contextError(ctx, err)
+}
+
+// NewEncoder returns an Encoder writing values as a multi-document stream.
+func (y YAML) NewEncoder(w io.Writer) Encoder {
+	return y.newEncoder(w)
+}
+
+func (y YAML) NewDecoder(r io.Reader) Decoder {
+	return y.newDecoder(r)
+}
+
+func (y YAML) newEncoder(w io.Writer) *yaml.Encoder {
+	encoder := yaml.NewEncoder(w)
+	if y.indent != "" {
+		encoder.SetIndent(len(y.indent))
+	}
+	return encoder
 }
 
-func (YAML) Unmarshal(data []byte, v interface{}) error {
-	return yaml.Unmarshal(data, v)
+func (y YAML) newDecoder(r io.Reader) *yaml.Decoder {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(y.disallowUnknownFields)
+	return decoder
 }
 
 func (yaml YAML) Reverse() Encoding {
@@ -0,0 +1,132 @@
+package encodingx
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/aura-studio/reflectx"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	ErrZstdWrongValueType = errors.New("encoding zstd converts on wrong type value")
+)
+
+func init() {
+	register(NewZstd())
+}
+
+// ============================================================================
+// Zstd - Zstandard frames (RFC 8878)
+// WithLevel takes the zstd levels 1 to 22, mapped onto the closest level
+// the pure Go encoder has; WithDictionary takes a trained dictionary as
+// written by "zstd --train" or zstd.BuildDict.
+// ============================================================================
+
+// zstdDefaultLevel is the default level of the zstd command line tool.
+const zstdDefaultLevel = 3
+
+type Zstd struct {
+	options
+}
+
+// NewZstd creates a Zstd encoding, honoring WithName, WithLevel,
+// WithDictionary and the decompression limits.
+func NewZstd(opts ...DictionaryCompressOption) *Zstd {
+	return &Zstd{
+		options: newOptions(opts, DictionaryCompressOption.applyDictionaryCompress),
+	}
+}
+
+func (z Zstd) String() string {
+	return z.nameOr(reflectx.TypeName(z))
+}
+
+func (Zstd) Style() EncodingStyleType {
+	return EncodingStyleBytes
+}
+
+func (z Zstd) Marshal(v interface{}) ([]byte, error) {
+	return z.AppendMarshal(nil, v)
+}
+
+func (z Zstd) AppendMarshal(dst []byte, v interface{}) ([]byte, error) {
+	return z.appendMarshal(context.Background(), dst, v)
+}
+
+// MarshalContext is like Marshal but stops compressing once ctx is done.
+func (z Zstd) MarshalContext(ctx context.Context, v interface{}) ([]byte, error) {
+	return z.appendMarshal(ctx, nil, v)
+}
+
+func (z Zstd) appendMarshal(ctx context.Context, dst []byte, v interface{}) ([]byte, error) {
+	data, err := toBytes(v)
+	if err != nil {
+		return nil, ErrZstdWrongValueType
+	}
+	return zstdFormat.appendCompress(ctx, dst, data, z.levelOr(zstdDefaultLevel), z.dictionary())
+}
+
+func (z Zstd) Unmarshal(data []byte, v interface{}) error {
+	return z.UnmarshalContext(context.Background(), data, v)
+}
+
+// UnmarshalContext is like Unmarshal but stops decompressing once ctx is
+// done.
+func (z Zstd) UnmarshalContext(ctx context.Context, data []byte, v interface{}) error {
+	switch v := v.(type) {
+	case *Bytes:
+		decompressed, err := zstdFormat.decompress(ctx, data, z.dictionary(), z.decompressLimits())
+		if err != nil {
+			return err
+		}
+		v.Data = decompressed
+		return nil
+	default:
+		return ErrZstdWrongValueType
+	}
+}
+
+func (z Zstd) NewEncoder(w io.Writer) Encoder {
+	return newBytesEncoder(zstdFormat.newStreamWriter(w, z.levelOr(zstdDefaultLevel), z.dictionary()), ErrZstdWrongValueType)
+}
+
+func (z Zstd) NewDecoder(r io.Reader) Decoder {
+	return newBytesDecoder(zstdFormat.newStreamReader(r, z.dictionary(), z.decompressLimits()), ErrZstdWrongValueType)
+}
+
+func (z Zstd) Reverse() Encoding {
+	return z
+}
+
+// zstdFormat encodes and decodes on the calling goroutine. Empty data
+// still gets a frame, so that the output is always valid zstd.
+var zstdFormat = &compressFormat{
+	newWriter: func(w io.Writer, level int, dict []byte) (compressWriter, error) {
+		opts := []zstd.EOption{
+			zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)),
+			zstd.WithEncoderConcurrency(1),
+			zstd.WithZeroFrames(true),
+		}
+		if dict != nil {
+			opts = append(opts, zstd.WithEncoderDict(dict))
+		}
+		zw, err := zstd.NewWriter(w, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return zw, nil
+	},
+	newReader: func(r io.Reader, dict []byte) (io.ReadCloser, error) {
+		opts := []zstd.DOption{zstd.WithDecoderConcurrency(1)}
+		if dict != nil {
+			opts = append(opts, zstd.WithDecoderDicts(dict))
+		}
+		zr, err := zstd.NewReader(r, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	},
+}